@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	"log_service/internal/client"
@@ -11,7 +13,7 @@ import (
 
 func parseFlags() presentation.AMQPLogRequest {
 	logLevel := flag.String("log-level", "INFO", "Log level")
-	sourceService := flag.String("source-service", "", "Source service")
+	sourceService := flag.String("source-service", filepath.Base(os.Args[0]), "Source service")
 	destinationService := flag.String("destination-service", "", "Destination service")
 	requestType := flag.String("request-type", "", "Request type")
 	content := flag.String("content", "", "Log content")
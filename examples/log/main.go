@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"time"
@@ -15,19 +16,41 @@ func init() {
 }
 
 func main() {
-	conn, ch, err := rabbitmq.Connect()
-	if err != nil {
-		panic(err)
-	}
-	defer conn.Close()
-	defer ch.Close()
+	async := flag.Bool("async", false, "Publish the logs without waiting for the server to store them")
+	spool := flag.String("spool", "", "Spool the logs in this directory, which keeps them while RabbitMQ is unavailable")
+	flag.Parse()
 
 	ctx := context.Background()
-	w, err := logger.NewWritter(ctx, ch)
-	if err != nil {
-		panic(err)
+	if *spool != "" {
+		w, err := logger.NewSpoolWritter(logger.SpoolConfig{Dir: *spool})
+		if err != nil {
+			panic(err)
+		}
+		defer w.Close()
+		log.SetOutput(w)
+	} else {
+		conn, ch, err := rabbitmq.Connect()
+		if err != nil {
+			panic(err)
+		}
+		defer conn.Close()
+		defer ch.Close()
+
+		if *async {
+			w, err := logger.NewAsyncWritter(ch, logger.AsyncConfig{})
+			if err != nil {
+				panic(err)
+			}
+			defer w.Close()
+			log.SetOutput(w)
+		} else {
+			w, err := logger.NewWritter(ctx, ch)
+			if err != nil {
+				panic(err)
+			}
+			log.SetOutput(w)
+		}
 	}
-	log.SetOutput(w)
 
 	num := 10
 	var sumSec int64 = 0
@@ -40,4 +63,14 @@ func main() {
 	}
 
 	fmt.Printf("Average: %vms\n", int(sumSec)/num)
+
+	if w, ok := log.Writer().(interface{ Flush(context.Context) error }); ok {
+		t := time.Now()
+		flushCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := w.Flush(flushCtx); err != nil {
+			panic(err)
+		}
+		fmt.Printf("Flush: %vms\n", time.Since(t).Milliseconds())
+	}
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"log_service/pkg/logger"
+	"log_service/pkg/rabbitmq"
+)
+
+func main() {
+	conn, ch, err := rabbitmq.Connect()
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	defer ch.Close()
+
+	w, err := logger.NewAsyncWritter(ch, logger.AsyncConfig{})
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+
+	slog.SetDefault(slog.New(logger.NewHandler(w, &logger.HandlerOptions{SourceService: "ExampleService"})))
+
+	slog.Info("user created", "destination_service", "UserService", "request_type", "POST",
+		slog.Group("user", "id", 42, "name", "alice"))
+	slog.Warn("slow request", "elapsed", 1500*time.Millisecond)
+
+	if err := w.Flush(context.Background()); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,183 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// ErrUnroutable is returned when the broker has no queue to route a
+	// message to, such as when the queue of the server was not declared.
+	ErrUnroutable = errors.New("message was returned as unroutable by the broker")
+	// ErrNacked is returned when the broker refuses to take a message.
+	ErrNacked = errors.New("message was nacked by the broker")
+)
+
+// Publisher publishes mandatory messages on a channel in confirm mode, and
+// reports for every message whether the broker routed and stored it.
+type Publisher struct {
+	ch *amqp.Channel
+
+	mu sync.Mutex
+	// pending are the confirmations waiting for the broker, by delivery tag.
+	pending map[uint64]*Confirmation
+	// acked are the broker confirmations received before their publishing
+	// was registered in pending, by delivery tag.
+	acked map[uint64]bool
+	// returned are the returned messages waiting for their confirmation, by
+	// message id. The broker returns a message before confirming it.
+	returned map[string]amqp.Return
+	closed   bool
+}
+
+// Confirmation is the outcome of a publishing.
+type Confirmation struct {
+	messageID string
+	done      chan struct{}
+	err       error
+}
+
+// NewPublisher puts ch in confirm mode and starts dispatching its returns and
+// confirmations. ch must not be used to publish other than through the
+// Publisher.
+func NewPublisher(ch *amqp.Channel) (*Publisher, error) {
+	if err := ch.Confirm(false); err != nil {
+		return nil, err
+	}
+	p := &Publisher{
+		ch:       ch,
+		pending:  make(map[uint64]*Confirmation),
+		acked:    make(map[uint64]bool),
+		returned: make(map[string]amqp.Return),
+	}
+	// The channels are unbuffered so that a return is dispatched before the
+	// confirmation that follows it.
+	returns := ch.NotifyReturn(make(chan amqp.Return))
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation))
+	go p.dispatch(returns, confirms)
+	return p, nil
+}
+
+// Publish publishes msg as a mandatory message and returns its confirmation.
+// The MessageId of msg is used to match it with its return, and is set to a
+// random UUID when empty.
+func (p *Publisher) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (*Confirmation, error) {
+	if msg.MessageId == "" {
+		msg.MessageId = uuid.New().String()
+	}
+	deferred, err := p.ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		exchange,
+		key,
+		true,  // mandatory
+		false, // immediate
+		msg)
+	if err != nil {
+		return nil, err
+	}
+	return p.register(deferred.DeliveryTag, msg.MessageId), nil
+}
+
+// register returns the confirmation of the publishing with the given
+// delivery tag, which the broker may already have confirmed.
+func (p *Publisher) register(tag uint64, messageID string) *Confirmation {
+	c := &Confirmation{messageID: messageID, done: make(chan struct{})}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch ack, ok := p.acked[tag]; {
+	case ok:
+		delete(p.acked, tag)
+		p.resolve(c, ack)
+	case p.closed:
+		c.settle(amqp.ErrClosed)
+	default:
+		p.pending[tag] = c
+	}
+	return c
+}
+
+// dispatch settles the confirmations as the broker returns and confirms the
+// messages, until the channel is closed.
+func (p *Publisher) dispatch(returns <-chan amqp.Return, confirms <-chan amqp.Confirmation) {
+	for {
+		select {
+		case r, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			p.mu.Lock()
+			p.returned[r.MessageId] = r
+			p.mu.Unlock()
+
+		case confirmed, ok := <-confirms:
+			if !ok {
+				p.close()
+				return
+			}
+			p.mu.Lock()
+			if c, ok := p.pending[confirmed.DeliveryTag]; ok {
+				delete(p.pending, confirmed.DeliveryTag)
+				p.resolve(c, confirmed.Ack)
+			} else {
+				p.acked[confirmed.DeliveryTag] = confirmed.Ack
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// resolve settles c once the broker confirmed it. p.mu must be held.
+func (p *Publisher) resolve(c *Confirmation, ack bool) {
+	r, returned := p.returned[c.messageID]
+	delete(p.returned, c.messageID)
+	switch {
+	case returned:
+		c.settle(fmt.Errorf("%w: %d %s", ErrUnroutable, r.ReplyCode, r.ReplyText))
+	case !ack:
+		c.settle(ErrNacked)
+	default:
+		c.settle(nil)
+	}
+}
+
+// close fails the pending confirmations once the channel is closed.
+func (p *Publisher) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for _, c := range p.pending {
+		c.settle(amqp.ErrClosed)
+	}
+	clear(p.pending)
+	clear(p.acked)
+	clear(p.returned)
+}
+
+func (c *Confirmation) settle(err error) {
+	c.err = err
+	close(c.done)
+}
+
+// Done returns a channel closed once the outcome of the publishing is known.
+func (c *Confirmation) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait waits for the broker to confirm the publishing. It returns nil when
+// the message was routed and stored, ErrUnroutable when it was returned,
+// ErrNacked when it was refused, and amqp.ErrClosed when the channel was
+// closed first.
+func (c *Confirmation) Wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func newTestPublisher() (p *Publisher, returns chan amqp.Return, confirms chan amqp.Confirmation, done chan struct{}) {
+	p = &Publisher{
+		pending:  make(map[uint64]*Confirmation),
+		acked:    make(map[uint64]bool),
+		returned: make(map[string]amqp.Return),
+	}
+	returns = make(chan amqp.Return)
+	confirms = make(chan amqp.Confirmation)
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		p.dispatch(returns, confirms)
+	}()
+	return p, returns, confirms, done
+}
+
+func TestPublisherConfirmations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		// registerFirst registers the publishing before the broker confirms it.
+		registerFirst bool
+		returned      bool
+		ack           bool
+		wantErr       error
+	}{
+		{name: "acked", registerFirst: true, ack: true},
+		{name: "acked before registration", ack: true},
+		{name: "nacked", registerFirst: true, wantErr: ErrNacked},
+		{name: "nacked before registration", wantErr: ErrNacked},
+		{name: "returned", registerFirst: true, returned: true, ack: true, wantErr: ErrUnroutable},
+		{name: "returned before registration", returned: true, ack: true, wantErr: ErrUnroutable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, returns, confirms, done := newTestPublisher()
+
+			var c *Confirmation
+			if tt.registerFirst {
+				c = p.register(1, "id-1")
+			}
+			if tt.returned {
+				returns <- amqp.Return{MessageId: "id-1", ReplyCode: amqp.NoRoute, ReplyText: "NO_ROUTE"}
+			}
+			confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: tt.ack}
+			if !tt.registerFirst {
+				// Sending a confirmation of another publishing makes sure the
+				// previous one was dispatched.
+				confirms <- amqp.Confirmation{DeliveryTag: 2, Ack: true}
+				c = p.register(1, "id-1")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := c.Wait(ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Wait() error = %v, want %v", err, tt.wantErr)
+			}
+
+			close(confirms)
+			<-done
+		})
+	}
+}
+
+func TestPublisherClosed(t *testing.T) {
+	t.Parallel()
+	p, _, confirms, done := newTestPublisher()
+
+	pending := p.register(1, "id-1")
+	close(confirms)
+	<-done
+	late := p.register(2, "id-2")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for name, c := range map[string]*Confirmation{"pending": pending, "registered after close": late} {
+		if err := c.Wait(ctx); !errors.Is(err, amqp.ErrClosed) {
+			t.Errorf("Wait() of the %s publishing error = %v, want %v", name, err, amqp.ErrClosed)
+		}
+	}
+}
+
+func TestConfirmationWaitContext(t *testing.T) {
+	t.Parallel()
+	p, _, confirms, done := newTestPublisher()
+	defer func() {
+		close(confirms)
+		<-done
+	}()
+
+	c := p.register(1, "id-1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,57 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+
+	"log_service/internal/server/presentation"
+	"log_service/internal/utils"
+)
+
+var (
+	// ErrNoResponse is returned when the reply queue is closed before the
+	// server responded.
+	ErrNoResponse = errors.New("reply queue closed before the log service responded")
+	// ErrInvalidArgument is wrapped by the ResponseError of a log rejected by
+	// the server.
+	ErrInvalidArgument = errors.New("invalid log request")
+	// ErrInternal is wrapped by the ResponseError of a log the server failed
+	// to store.
+	ErrInternal = errors.New("log service failed to store the log")
+)
+
+// ResponseError is returned when the server responds with a status other
+// than utils.OK. It wraps ErrInvalidArgument or ErrInternal depending on the
+// status.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+	// Errors lists the invalid fields of a rejected request.
+	Errors []presentation.FieldError
+}
+
+func newResponseError(res *presentation.AmqpLogResponse) error {
+	if res.StatusCode == utils.OK {
+		return nil
+	}
+	return &ResponseError{
+		StatusCode: res.StatusCode,
+		Message:    res.Message,
+		Errors:     res.Errors,
+	}
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("log service responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *ResponseError) Unwrap() error {
+	switch e.StatusCode {
+	case utils.INVALID_ARGUMENT:
+		return ErrInvalidArgument
+	case utils.INTERNAL:
+		return ErrInternal
+	default:
+		return nil
+	}
+}
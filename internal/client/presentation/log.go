@@ -6,6 +6,7 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	clientRabbitmq "log_service/internal/client/infrastructure/rabbitmq"
 	"log_service/internal/server/infrastructure/rabbitmq"
 	"log_service/internal/server/presentation"
 )
@@ -13,39 +14,49 @@ import (
 type ILogPresentation interface {
 	Publish(ctx context.Context, queueName string, id string, req presentation.AMQPLogRequest) error
 	Consume() (<-chan amqp.Delivery, string, error)
-	Serve(msgs <-chan amqp.Delivery, id string) error
+	Serve(ctx context.Context, msgs <-chan amqp.Delivery, id string) error
 }
 
 type LogPresentation struct {
-	ch *amqp.Channel
+	ch        *amqp.Channel
+	publisher *clientRabbitmq.Publisher
 }
 
-func NewLogPresentation(ch *amqp.Channel) *LogPresentation {
-	return &LogPresentation{
-		ch: ch,
+// NewLogPresentation puts ch in confirm mode, so that Publish knows whether
+// the broker took the log.
+func NewLogPresentation(ch *amqp.Channel) (*LogPresentation, error) {
+	publisher, err := clientRabbitmq.NewPublisher(ch)
+	if err != nil {
+		return nil, err
 	}
+	return &LogPresentation{
+		ch:        ch,
+		publisher: publisher,
+	}, nil
 }
 
+// Publish publishes req and waits for the broker to confirm it. It returns
+// clientRabbitmq.ErrUnroutable when the log queue does not exist and
+// clientRabbitmq.ErrNacked when the broker refuses the log.
 func (r *LogPresentation) Publish(ctx context.Context, queueName string, id string, req presentation.AMQPLogRequest) error {
 	bytes, err := json.Marshal(req)
 	if err != nil {
 		return err
 	}
-	err = r.ch.Publish(
-		"",                  // exchange
-		rabbitmq.QUEUE_NAME, // routing key
-		false,               // mandatory
-		false,               // immediate
+	confirmation, err := r.publisher.Publish(ctx,
+		"",                      // exchange
+		rabbitmq.LOG_QUEUE_NAME, // routing key
 		amqp.Publishing{
 			ContentType:   "text/plain",
 			ReplyTo:       queueName,
 			CorrelationId: id,
+			MessageId:     id,
 			Body:          bytes,
 		})
 	if err != nil {
 		return err
 	}
-	return nil
+	return confirmation.Wait(ctx)
 }
 
 func (r *LogPresentation) Consume() (<-chan amqp.Delivery, string, error) {
@@ -75,15 +86,28 @@ func (r *LogPresentation) Consume() (<-chan amqp.Delivery, string, error) {
 	return msgs, q.Name, nil
 }
 
-func (r *LogPresentation) Serve(msgs <-chan amqp.Delivery, id string) error {
-	for d := range msgs {
-		if d.CorrelationId == id {
+// Serve waits for the response of the server to the request with the given
+// correlation id, acknowledging the responses to other requests. It returns a
+// *ResponseError when the server did not store the log, ErrNoResponse when
+// msgs is closed first and the error of ctx when it is done first.
+func (r *LogPresentation) Serve(ctx context.Context, msgs <-chan amqp.Delivery, id string) error {
+	for {
+		select {
+		case d, ok := <-msgs:
+			if !ok {
+				return ErrNoResponse
+			}
+			d.Ack(false)
+			if d.CorrelationId != id {
+				continue
+			}
 			res := &presentation.AmqpLogResponse{}
 			if err := json.Unmarshal(d.Body, res); err != nil {
 				return err
 			}
-			break
+			return newResponseError(res)
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
-	return nil
 }
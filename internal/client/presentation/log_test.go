@@ -0,0 +1,101 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"log_service/internal/server/presentation"
+	"log_service/internal/utils"
+)
+
+func response(t *testing.T, corrID string, res presentation.AmqpLogResponse) amqp.Delivery {
+	t.Helper()
+	body, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amqp.Delivery{CorrelationId: corrID, Body: body}
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		deliveries []amqp.Delivery
+		close      bool
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			name: "ok",
+			deliveries: []amqp.Delivery{
+				response(t, "id", presentation.AmqpLogResponse{StatusCode: utils.OK, ID: "log-id"}),
+			},
+		},
+		{
+			name: "skips the responses to other requests",
+			deliveries: []amqp.Delivery{
+				response(t, "other", presentation.AmqpLogResponse{StatusCode: utils.INTERNAL}),
+				response(t, "id", presentation.AmqpLogResponse{StatusCode: utils.OK}),
+			},
+		},
+		{
+			name: "invalid argument",
+			deliveries: []amqp.Delivery{
+				response(t, "id", presentation.AmqpLogResponse{StatusCode: utils.INVALID_ARGUMENT, Message: "Invalid log request"}),
+			},
+			wantErr:    ErrInvalidArgument,
+			wantStatus: utils.INVALID_ARGUMENT,
+		},
+		{
+			name: "internal",
+			deliveries: []amqp.Delivery{
+				response(t, "id", presentation.AmqpLogResponse{StatusCode: utils.INTERNAL, Message: "Failed to insert log"}),
+			},
+			wantErr:    ErrInternal,
+			wantStatus: utils.INTERNAL,
+		},
+		{
+			name:    "reply queue closed",
+			close:   true,
+			wantErr: ErrNoResponse,
+		},
+		{
+			name:    "no response",
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			msgs := make(chan amqp.Delivery, len(tt.deliveries))
+			for _, d := range tt.deliveries {
+				msgs <- d
+			}
+			if tt.close {
+				close(msgs)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := (&LogPresentation{}).Serve(ctx, msgs, "id")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Serve() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var resErr *ResponseError
+			if errors.As(err, &resErr) != (tt.wantStatus != utils.OK) {
+				t.Fatalf("Serve() error = %v, want a ResponseError: %v", err, tt.wantStatus != utils.OK)
+			}
+			if resErr != nil && resErr.StatusCode != tt.wantStatus {
+				t.Errorf("ResponseError.StatusCode = %d, want %d", resErr.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package presentation
+
+import (
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ReplyDispatcher demultiplexes the responses read from a reply queue to the
+// requests waiting for them, by correlation id, so that concurrent requests
+// can share the queue.
+type ReplyDispatcher struct {
+	mu      sync.Mutex
+	waiters map[string]chan amqp.Delivery
+	closed  bool
+}
+
+// NewReplyDispatcher starts dispatching msgs until it is closed.
+func NewReplyDispatcher(msgs <-chan amqp.Delivery) *ReplyDispatcher {
+	d := &ReplyDispatcher{waiters: make(map[string]chan amqp.Delivery)}
+	go d.dispatch(msgs)
+	return d
+}
+
+// Register returns the channel receiving the response to the request with
+// the given correlation id, which is closed without a response if the reply
+// queue is closed first. It must be called before publishing the request,
+// and cancel must be called once the response is no longer awaited.
+func (d *ReplyDispatcher) Register(id string) (replies <-chan amqp.Delivery, cancel func()) {
+	ch := make(chan amqp.Delivery, 1)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	d.waiters[id] = ch
+	return ch, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.waiters[id] == ch {
+			delete(d.waiters, id)
+		}
+	}
+}
+
+// dispatch hands every response to its waiter. The responses nobody waits
+// for, such as the late responses to timed out requests, are acknowledged
+// and dropped.
+func (d *ReplyDispatcher) dispatch(msgs <-chan amqp.Delivery) {
+	for msg := range msgs {
+		d.mu.Lock()
+		ch, ok := d.waiters[msg.CorrelationId]
+		delete(d.waiters, msg.CorrelationId)
+		d.mu.Unlock()
+
+		if !ok {
+			msg.Ack(false)
+			continue
+		}
+		// The channel is buffered and only receives this response.
+		ch <- msg
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+	for _, ch := range d.waiters {
+		close(ch)
+	}
+	clear(d.waiters)
+}
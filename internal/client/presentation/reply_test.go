@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestReplyDispatcherConcurrentRequests(t *testing.T) {
+	t.Parallel()
+	const n = 100
+	msgs := make(chan amqp.Delivery)
+	d := NewReplyDispatcher(msgs)
+	defer close(msgs)
+
+	var registered, wg sync.WaitGroup
+	registered.Add(n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprint(i)
+			replies, cancel := d.Register(id)
+			defer cancel()
+			registered.Done()
+
+			select {
+			case msg := <-replies:
+				if msg.CorrelationId != id {
+					t.Errorf("request %s got the response to %s", id, msg.CorrelationId)
+				}
+			case <-time.After(5 * time.Second):
+				t.Errorf("request %s got no response", id)
+			}
+		}()
+	}
+
+	registered.Wait()
+	// Respond in reverse order, with responses nobody waits for in between.
+	for i := n - 1; i >= 0; i-- {
+		msgs <- amqp.Delivery{CorrelationId: fmt.Sprint(i)}
+		msgs <- amqp.Delivery{CorrelationId: fmt.Sprintf("unknown-%d", i)}
+	}
+	wg.Wait()
+}
+
+func TestReplyDispatcherCancel(t *testing.T) {
+	t.Parallel()
+	msgs := make(chan amqp.Delivery)
+	d := NewReplyDispatcher(msgs)
+	defer close(msgs)
+
+	replies, cancel := d.Register("id")
+	cancel()
+	msgs <- amqp.Delivery{CorrelationId: "id"}
+	// The dispatcher handles the next delivery only once the previous one
+	// was dropped.
+	msgs <- amqp.Delivery{CorrelationId: "other"}
+
+	select {
+	case msg := <-replies:
+		t.Errorf("canceled request got response %q", msg.CorrelationId)
+	default:
+	}
+}
+
+func TestReplyDispatcherClosed(t *testing.T) {
+	t.Parallel()
+	msgs := make(chan amqp.Delivery)
+	d := NewReplyDispatcher(msgs)
+
+	pending, cancel := d.Register("pending")
+	defer cancel()
+	close(msgs)
+
+	select {
+	case _, ok := <-pending:
+		if ok {
+			t.Fatalf("pending request got a response")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("pending request was not closed")
+	}
+
+	late, cancel := d.Register("late")
+	defer cancel()
+	if _, ok := <-late; ok {
+		t.Errorf("request registered after close got a response")
+	}
+}
@@ -15,7 +15,10 @@ func Run(req presentation.AMQPLogRequest) error {
 	defer conn.Close()
 	defer ch.Close()
 
-	logPresentation := clientPresentation.NewLogPresentation(ch)
+	logPresentation, err := clientPresentation.NewLogPresentation(ch)
+	if err != nil {
+		return err
+	}
 	logUseCase := usecase.NewInsertLogUseCase(logPresentation)
 
 	return logUseCase.Serve(req)
@@ -10,6 +10,10 @@ import (
 	"log_service/internal/server/presentation"
 )
 
+// DefaultTimeout bounds the time to publish a log and get the response of the
+// server.
+const DefaultTimeout = 5 * time.Second
+
 type IInsertLogUseCase interface {
 	Serve() error
 }
@@ -24,13 +28,17 @@ func NewInsertLogUseCase(logPresentation clientPresentation.ILogPresentation) *I
 	}
 }
 
+// Serve publishes req and waits up to DefaultTimeout for the server to reply.
+// The errors of the publishing, such as the broker refusing the log, and the
+// *clientPresentation.ResponseError of a log the server did not store are
+// returned as is.
 func (u *InsertLogUseCase) Serve(req presentation.AMQPLogRequest) error {
 	msgs, qName, err := u.logPresentation.Consume()
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
 	defer cancel()
 
 	corrID := uuid.New().String()
@@ -39,5 +47,5 @@ func (u *InsertLogUseCase) Serve(req presentation.AMQPLogRequest) error {
 		return err
 	}
 
-	return u.logPresentation.Serve(msgs, corrID)
+	return u.logPresentation.Serve(ctx, msgs, corrID)
 }
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// CTRBucket is the width of the time buckets CTR events are counted in.
+type CTRBucket string
+
+const (
+	CTRBucketMinute CTRBucket = "minute"
+	CTRBucketHour   CTRBucket = "hour"
+	CTRBucketDay    CTRBucket = "day"
+)
+
+// Truncate returns the start of the bucket containing t, in UTC.
+func (b CTRBucket) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch b {
+	case CTRBucketMinute:
+		return t.Truncate(time.Minute)
+	case CTRBucketHour:
+		return t.Truncate(time.Hour)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// CTRStatsSort is the measure objects are ranked by, in descending order.
+type CTRStatsSort string
+
+const (
+	CTRStatsSortImpressions CTRStatsSort = "impressions"
+	CTRStatsSortClicks      CTRStatsSort = "clicks"
+	CTRStatsSortCTR         CTRStatsSort = "ctr"
+)
+
+// CTRStatsFilter selects the CTR events counted by ILogRepository.CTRStats
+// and ILogRepository.CTRTopObjects.
+type CTRStatsFilter struct {
+	// From is the inclusive lower bound of the event time.
+	From time.Time
+	// To is the exclusive upper bound of the event time.
+	To     time.Time
+	Bucket CTRBucket
+	// ObjectIDs are the objects counted by ILogRepository.CTRStats.
+	ObjectIDs []string
+}
+
+// CTRTotal is the number of impressions and clicks of one object over the
+// whole time range of a filter.
+type CTRTotal struct {
+	ObjectID    string
+	Impressions int64
+	Clicks      int64
+}
+
+// CTRStat is the number of impressions and clicks of one object within one time bucket.
+type CTRStat struct {
+	ObjectID string
+	// BucketStart is the start of the time bucket.
+	BucketStart time.Time
+	Impressions int64
+	Clicks      int64
+}
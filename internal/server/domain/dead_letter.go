@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrDeadLetterNotFound is returned when no dead-lettered message matches the requested ID.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrUnknownQueue is returned when a queue has no dead-letter queue.
+	ErrUnknownQueue = errors.New("unknown queue")
+)
+
+// DeadLetter is a message that was given up on and parked in the
+// dead-letter queue of its original queue.
+type DeadLetter struct {
+	ID string
+	// Queue is the queue the message was originally published to.
+	Queue string
+	// Reason is the error that caused the message to be dead-lettered.
+	Reason         string
+	DeadLetteredAt time.Time
+	// Retries is how many times the message was retried before it was given up on.
+	Retries     int
+	ContentType string
+	Body        []byte
+}
+
+// IDeadLetterRepository gives access to the dead-letter queue of every queue
+// consumed by the server. Queues are named after their original queue.
+type IDeadLetterRepository interface {
+	// ListDeadLetters returns the first limit messages of the dead-letter
+	// queue, oldest first, along with the number of messages in the queue.
+	ListDeadLetters(ctx context.Context, queue string, limit int) ([]*DeadLetter, int, error)
+	// GetDeadLetter returns the message with the given ID without removing it.
+	GetDeadLetter(ctx context.Context, queue, id string) (*DeadLetter, error)
+	// ReplayDeadLetters publishes the messages with the given IDs, or all of
+	// them if ids is empty, back to their original queue, and returns how
+	// many were replayed.
+	ReplayDeadLetters(ctx context.Context, queue string, ids []string) (int, error)
+	// PurgeDeadLetters deletes the messages with the given IDs, or all of them
+	// if ids is empty, and returns how many were deleted.
+	PurgeDeadLetters(ctx context.Context, queue string, ids []string) (int, error)
+}
@@ -1,20 +1,43 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Log struct {
+	ID                 string
 	LogLevel           string
 	Date               time.Time
 	DestinationService string
 	SourceService      string
 	RequestType        string
 	Content            string
+	// Attributes are structured key/values attached to the log, such as a
+	// user ID or an HTTP status. Values are JSON-compatible.
+	Attributes map[string]any
+	// TraceID identifies the distributed trace the log belongs to, as 32
+	// lowercase hex digits. It is empty when the log is not part of a trace.
+	TraceID string
+	// SpanID identifies the span in which the log was written, as 16 lowercase hex digits.
+	SpanID string
+	// ParentSpanID identifies the parent of that span, as 16 lowercase hex digits.
+	ParentSpanID string
 }
 
+// Event types of a CTRLog used to compute click-through rates.
+const (
+	CTREventImpression = "impression"
+	CTREventClick      = "click"
+)
+
 // CTRLog represents a log entry for tracking user interactions with a page element.
 //
 // The zero value of CTRLog is not valid for use; values should be explicitly initialized.
 type CTRLog struct {
+	// ID is the server-generated identifier of the event.
+	ID string
 	// EventType specifies the type of interaction, such as "click" or "impression".
 	EventType string
 	// CreatedAt is the timestamp when the event occurred.
@@ -23,38 +46,62 @@ type CTRLog struct {
 	ObjectID string
 }
 
+// NewLogID returns a new identifier for a Log or CTRLog.
+//
+// IDs are UUIDv7 strings, so they sort in the order in which they were issued.
+func NewLogID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
 func NewLog(
+	id string,
 	logLevel string,
 	date time.Time,
 	destinationService string,
 	sourceService string,
 	requestType string,
 	content string,
+	attributes map[string]any,
+	traceID string,
+	spanID string,
+	parentSpanID string,
 ) *Log {
 	return &Log{
+		ID:                 id,
 		LogLevel:           logLevel,
 		Date:               date,
 		DestinationService: destinationService,
 		SourceService:      sourceService,
 		RequestType:        requestType,
 		Content:            content,
+		Attributes:         attributes,
+		TraceID:            traceID,
+		SpanID:             spanID,
+		ParentSpanID:       parentSpanID,
 	}
 }
 
-// NewCTRLog creates a new CTRLog instance with the specified event type,
+// NewCTRLog creates a new CTRLog instance with the specified ID, event type,
 // creation timestamp, and associated object ID.
 //
+// id is the identifier of the event, usually obtained from NewLogID.
 // eventType indicates the type of interaction, such as "click" or "impression".
 // createdAt specifies the timestamp when the event occurred.
 // objectid uniquely identifies the page element related to the event.
 //
 // Returns a pointer to a CTRLog instance initialized with the provided values.
 func NewCTRLog(
+	id string,
 	eventType string,
 	createdAt time.Time,
 	objectID string,
 ) *CTRLog {
 	return &CTRLog{
+		ID:        id,
 		EventType: eventType,
 		CreatedAt: createdAt,
 		ObjectID:  objectID,
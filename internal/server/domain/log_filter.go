@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SortOrder is the order in which log entries are returned, by date and then by ID.
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// LogCursor identifies the position of a log entry in a sorted result set.
+// Entries are returned strictly after the cursor in the requested order.
+type LogCursor struct {
+	Date time.Time
+	ID   string
+}
+
+// LogFilter narrows down and orders the log entries returned by ILogRepository.List.
+//
+// Zero-valued fields do not restrict the result.
+type LogFilter struct {
+	// Severity matches entries of exactly this severity.
+	Severity Severity
+	// MinSeverity matches entries of this severity or a more severe one.
+	MinSeverity        Severity
+	SourceService      string
+	DestinationService string
+	RequestType        string
+	// From is the inclusive lower bound of the log date.
+	From time.Time
+	// To is the exclusive upper bound of the log date.
+	To time.Time
+	// Content matches entries whose content contains it as a substring.
+	// Wildcard characters such as % and _ are matched literally.
+	Content string
+	// Attributes matches entries having all of these attributes with equal values.
+	Attributes map[string]any
+	Order      SortOrder
+	// After resumes the listing after the given position.
+	After *LogCursor
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+// Matches reports whether log satisfies the conditions of the filter.
+// Order, After and Limit are ignored.
+//
+// Texts are compared regardless of case, like the case-insensitive collation
+// of the logs table compares them in ILogRepository.List.
+func (f *LogFilter) Matches(log *Log) bool {
+	// Unknown levels have SeverityUnspecified, which no filter matches.
+	severity, _ := ParseSeverity(log.LogLevel)
+	switch {
+	case f.Severity != SeverityUnspecified && severity != f.Severity,
+		f.MinSeverity != SeverityUnspecified && severity < f.MinSeverity,
+		f.SourceService != "" && !strings.EqualFold(log.SourceService, f.SourceService),
+		f.DestinationService != "" && !strings.EqualFold(log.DestinationService, f.DestinationService),
+		f.RequestType != "" && !strings.EqualFold(log.RequestType, f.RequestType),
+		!f.From.IsZero() && log.Date.Before(f.From),
+		!f.To.IsZero() && !log.Date.Before(f.To),
+		f.Content != "" && !containsFold(log.Content, f.Content):
+		return false
+	}
+	for key, want := range f.Attributes {
+		got, ok := log.Attributes[key]
+		if !ok || !reflect.DeepEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold reports whether substr is within s regardless of case.
+func containsFold(s, substr string) bool {
+	n := utf8.RuneCountInString(substr)
+	for i := range s {
+		// Case folding maps runes one to one, so a match has as many runes
+		// as substr.
+		end := i
+		for k := 0; k < n && end < len(s); k++ {
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+		}
+		if strings.EqualFold(s[i:end], substr) {
+			return true
+		}
+	}
+	return false
+}
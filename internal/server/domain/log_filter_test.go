@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogFilterMatches(t *testing.T) {
+	t.Parallel()
+	date := time.Date(2024, 9, 23, 12, 0, 0, 0, time.UTC)
+	log := &Log{
+		LogLevel:           "ERROR",
+		Date:               date,
+		DestinationService: "UserService",
+		SourceService:      "AuthService",
+		RequestType:        "POST",
+		Content:            "failed to create user",
+		Attributes:         map[string]any{"status": float64(500), "user_id": "u-1"},
+	}
+
+	testCases := map[string]struct {
+		filter LogFilter
+		want   bool
+	}{
+		"empty filter":           {filter: LogFilter{}, want: true},
+		"all fields match":       {filter: LogFilter{Severity: SeverityError, SourceService: "AuthService", DestinationService: "UserService", RequestType: "POST", Content: "create"}, want: true},
+		"other level":            {filter: LogFilter{Severity: SeverityInfo}, want: false},
+		"min level below":        {filter: LogFilter{MinSeverity: SeverityWarn}, want: true},
+		"min level equal":        {filter: LogFilter{MinSeverity: SeverityError}, want: true},
+		"min level above":        {filter: LogFilter{MinSeverity: SeverityFatal}, want: false},
+		"other source":           {filter: LogFilter{SourceService: "UserService"}, want: false},
+		"other destination":      {filter: LogFilter{DestinationService: "AuthService"}, want: false},
+		"other request type":     {filter: LogFilter{RequestType: "GET"}, want: false},
+		"content not contained":  {filter: LogFilter{Content: "deleted"}, want: false},
+		"content of other case":  {filter: LogFilter{Content: "CREATE User"}, want: true},
+		"services of other case": {filter: LogFilter{SourceService: "authservice", DestinationService: "USERSERVICE", RequestType: "post"}, want: true},
+		"percent is literal":     {filter: LogFilter{Content: "failed%user"}, want: false},
+		"underscore is literal":  {filter: LogFilter{Content: "failed_to"}, want: false},
+		"from is inclusive":      {filter: LogFilter{From: date}, want: true},
+		"before from":            {filter: LogFilter{From: date.Add(time.Second)}, want: false},
+		"to is exclusive":        {filter: LogFilter{To: date}, want: false},
+		"before to":              {filter: LogFilter{To: date.Add(time.Second)}, want: true},
+		"limit is ignored":       {filter: LogFilter{Limit: 1, Order: SortOrderAsc}, want: true},
+		"attributes match":       {filter: LogFilter{Attributes: map[string]any{"status": float64(500), "user_id": "u-1"}}, want: true},
+		"other attribute value":  {filter: LogFilter{Attributes: map[string]any{"status": "500"}}, want: false},
+		"missing attribute":      {filter: LogFilter{Attributes: map[string]any{"latency": float64(1)}}, want: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.filter.Matches(log); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContainsFold(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		s, substr string
+		want      bool
+	}{
+		{s: "Hello, World", substr: "o, w", want: true},
+		{s: "Hello, World", substr: "WORLD", want: true},
+		{s: "Hello, World", substr: "worlds", want: false},
+		{s: "ÉCOLE", substr: "éco", want: true},
+		// The Kelvin sign folds to k while being longer in UTF-8.
+		{s: "\u212a", substr: "k", want: true},
+		{s: "", substr: "a", want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := containsFold(tc.s, tc.substr); got != tc.want {
+			t.Errorf("containsFold(%q, %q) = %v, want %v", tc.s, tc.substr, got, tc.want)
+		}
+	}
+}
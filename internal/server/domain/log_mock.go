@@ -12,6 +12,7 @@ package domain
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,19 +55,123 @@ func (mr *MockILogRepositoryMockRecorder) CTRSave(ctx, ctrLog any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CTRSave", reflect.TypeOf((*MockILogRepository)(nil).CTRSave), ctx, ctrLog)
 }
 
+// CTRSaveBatch mocks base method.
+func (m *MockILogRepository) CTRSaveBatch(ctx context.Context, ctrLogs []*CTRLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CTRSaveBatch", ctx, ctrLogs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CTRSaveBatch indicates an expected call of CTRSaveBatch.
+func (mr *MockILogRepositoryMockRecorder) CTRSaveBatch(ctx, ctrLogs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CTRSaveBatch", reflect.TypeOf((*MockILogRepository)(nil).CTRSaveBatch), ctx, ctrLogs)
+}
+
+// CTRStats mocks base method.
+func (m *MockILogRepository) CTRStats(ctx context.Context, filter *CTRStatsFilter) ([]CTRStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CTRStats", ctx, filter)
+	ret0, _ := ret[0].([]CTRStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CTRStats indicates an expected call of CTRStats.
+func (mr *MockILogRepositoryMockRecorder) CTRStats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CTRStats", reflect.TypeOf((*MockILogRepository)(nil).CTRStats), ctx, filter)
+}
+
+// CTRTopObjects mocks base method.
+func (m *MockILogRepository) CTRTopObjects(ctx context.Context, filter *CTRStatsFilter, sortBy CTRStatsSort, limit int) ([]CTRTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CTRTopObjects", ctx, filter, sortBy, limit)
+	ret0, _ := ret[0].([]CTRTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CTRTopObjects indicates an expected call of CTRTopObjects.
+func (mr *MockILogRepositoryMockRecorder) CTRTopObjects(ctx, filter, sortBy, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CTRTopObjects", reflect.TypeOf((*MockILogRepository)(nil).CTRTopObjects), ctx, filter, sortBy, limit)
+}
+
+// DeleteCTRStats mocks base method.
+func (m *MockILogRepository) DeleteCTRStats(ctx context.Context, bucket CTRBucket, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCTRStats", ctx, bucket, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCTRStats indicates an expected call of DeleteCTRStats.
+func (mr *MockILogRepositoryMockRecorder) DeleteCTRStats(ctx, bucket, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCTRStats", reflect.TypeOf((*MockILogRepository)(nil).DeleteCTRStats), ctx, bucket, before)
+}
+
+// Get mocks base method.
+func (m *MockILogRepository) Get(ctx context.Context, id string) (*Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockILogRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockILogRepository)(nil).Get), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockILogRepository) List(ctx context.Context) ([]Log, error) {
+func (m *MockILogRepository) List(ctx context.Context, filter *LogFilter) ([]Log, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
 	ret0, _ := ret[0].([]Log)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockILogRepositoryMockRecorder) List(ctx any) *gomock.Call {
+func (mr *MockILogRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockILogRepository)(nil).List), ctx, filter)
+}
+
+// ListTrace mocks base method.
+func (m *MockILogRepository) ListTrace(ctx context.Context, traceID string, limit int) ([]Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrace", ctx, traceID, limit)
+	ret0, _ := ret[0].([]Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrace indicates an expected call of ListTrace.
+func (mr *MockILogRepositoryMockRecorder) ListTrace(ctx, traceID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockILogRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrace", reflect.TypeOf((*MockILogRepository)(nil).ListTrace), ctx, traceID, limit)
+}
+
+// ServiceCallCounts mocks base method.
+func (m *MockILogRepository) ServiceCallCounts(ctx context.Context, filter *ServiceGraphFilter) ([]ServiceCallCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceCallCounts", ctx, filter)
+	ret0, _ := ret[0].([]ServiceCallCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceCallCounts indicates an expected call of ServiceCallCounts.
+func (mr *MockILogRepositoryMockRecorder) ServiceCallCounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallCounts", reflect.TypeOf((*MockILogRepository)(nil).ServiceCallCounts), ctx, filter)
 }
 
 // Save mocks base method.
@@ -82,3 +187,17 @@ func (mr *MockILogRepositoryMockRecorder) Save(ctx, log any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockILogRepository)(nil).Save), ctx, log)
 }
+
+// SaveBatch mocks base method.
+func (m *MockILogRepository) SaveBatch(ctx context.Context, logs []*Log) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBatch", ctx, logs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBatch indicates an expected call of SaveBatch.
+func (mr *MockILogRepositoryMockRecorder) SaveBatch(ctx, logs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBatch", reflect.TypeOf((*MockILogRepository)(nil).SaveBatch), ctx, logs)
+}
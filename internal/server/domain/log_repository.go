@@ -1,9 +1,35 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLogNotFound is returned when no log entry matches the requested ID.
+var ErrLogNotFound = errors.New("log not found")
 
 type ILogRepository interface {
 	Save(ctx context.Context, log *Log) error
+	// SaveBatch stores all the given logs atomically.
+	SaveBatch(ctx context.Context, logs []*Log) error
 	CTRSave(ctx context.Context, ctrLog *CTRLog) error
-	List(ctx context.Context) ([]Log, error)
+	// CTRSaveBatch stores all the given CTR logs atomically.
+	CTRSaveBatch(ctx context.Context, ctrLogs []*CTRLog) error
+	List(ctx context.Context, filter *LogFilter) ([]Log, error)
+	Get(ctx context.Context, id string) (*Log, error)
+	// ListTrace returns at most limit logs of the given trace, oldest first.
+	ListTrace(ctx context.Context, traceID string, limit int) ([]Log, error)
+	// ServiceCallCounts counts the logs sent between every pair of services,
+	// per log level. Logs without a source or destination service are ignored.
+	ServiceCallCounts(ctx context.Context, filter *ServiceGraphFilter) ([]ServiceCallCount, error)
+	// CTRTopObjects counts the impressions and clicks of every object and
+	// returns the first limit objects ranked by sortBy, ties broken by object ID.
+	CTRTopObjects(ctx context.Context, filter *CTRStatsFilter, sortBy CTRStatsSort, limit int) ([]CTRTotal, error)
+	// CTRStats counts the impressions and clicks of the objects of the filter
+	// per time bucket.
+	CTRStats(ctx context.Context, filter *CTRStatsFilter) ([]CTRStat, error)
+	// DeleteCTRStats deletes the counts of the given bucket width for the
+	// buckets starting before the given time and returns how many were deleted.
+	DeleteCTRStats(ctx context.Context, bucket CTRBucket, before time.Time) (int64, error)
 }
@@ -7,17 +7,25 @@ import (
 
 func TestNewLog(t *testing.T) {
 	// Setup test data
+	id := "0192b6a0-5b1e-7c4a-8f3e-9d2c1b0a0e1f"
 	logLevel := "INFO"
 	date := time.Now()
 	destinationService := "UserService"
 	sourceService := "AuthService"
 	requestType := "POST"
 	content := "User created successfully."
+	attributes := map[string]any{"user_id": "u-1"}
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID := "00f067aa0ba902b7"
+	parentSpanID := "b7ad6b7169203331"
 
 	// Call the function
-	log := NewLog(logLevel, date, destinationService, sourceService, requestType, content)
+	log := NewLog(id, logLevel, date, destinationService, sourceService, requestType, content, attributes, traceID, spanID, parentSpanID)
 
 	// Check if the log is populated correctly
+	if log.ID != id {
+		t.Errorf("Expected ID %s, got %s", id, log.ID)
+	}
 	if log.LogLevel != logLevel {
 		t.Errorf("Expected LogLevel %s, got %s", logLevel, log.LogLevel)
 	}
@@ -36,19 +44,29 @@ func TestNewLog(t *testing.T) {
 	if log.Content != content {
 		t.Errorf("Expected Content %s, got %s", content, log.Content)
 	}
+	if log.Attributes["user_id"] != "u-1" {
+		t.Errorf("Expected Attributes %v, got %v", attributes, log.Attributes)
+	}
+	if log.TraceID != traceID || log.SpanID != spanID || log.ParentSpanID != parentSpanID {
+		t.Errorf("Expected trace %s/%s/%s, got %s/%s/%s", traceID, spanID, parentSpanID, log.TraceID, log.SpanID, log.ParentSpanID)
+	}
 }
 
 // TestNewCTRLog tests the NewCTRLog function
 func TestNewCTRLog(t *testing.T) {
 	// Setup test data
+	id := "0192b6a0-5b1e-7c4a-8f3e-9d2c1b0a0e1f"
 	eventType := "click"
 	createdAt := time.Now()
 	objectID := "123456"
 
 	// Call the function
-	ctrLog := NewCTRLog(eventType, createdAt, objectID)
+	ctrLog := NewCTRLog(id, eventType, createdAt, objectID)
 
 	// Check if the log is populated correctly
+	if ctrLog.ID != id {
+		t.Errorf("Expected ID %s, got %s", id, ctrLog.ID)
+	}
 	if ctrLog.CreatedAt != createdAt {
 		t.Errorf("Expected CreatedAt %s, got %s", createdAt, ctrLog.CreatedAt)
 	}
@@ -59,3 +77,21 @@ func TestNewCTRLog(t *testing.T) {
 		t.Errorf("Expected EventType %s, got %s", eventType, ctrLog.EventType)
 	}
 }
+
+// TestNewLogID tests that NewLogID returns unique IDs that sort in issue order.
+func TestNewLogID(t *testing.T) {
+	prev, err := NewLogID()
+	if err != nil {
+		t.Fatalf("NewLogID() error = %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		id, err := NewLogID()
+		if err != nil {
+			t.Fatalf("NewLogID() error = %v", err)
+		}
+		if id <= prev {
+			t.Errorf("Expected %s to sort after %s", id, prev)
+		}
+		prev = id
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ServiceGraphFilter selects the logs counted by ILogRepository.ServiceCallCounts.
+type ServiceGraphFilter struct {
+	// From is the inclusive lower bound of the log date.
+	From time.Time
+	// To is the exclusive upper bound of the log date.
+	To time.Time
+}
+
+// ServiceCallCount is the number of logs of one level sent from one service
+// to another. Every such log is counted as one call.
+type ServiceCallCount struct {
+	SourceService      string
+	DestinationService string
+	LogLevel           string
+	Count              int64
+}
+
+// IsErrorLevel reports whether a log of the given level reports a failed
+// call, that is whether its severity is at least SeverityError.
+func IsErrorLevel(level string) bool {
+	severity, err := ParseSeverity(level)
+	return err == nil && severity >= SeverityError
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestIsErrorLevel(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]bool{
+		"ERROR":    true,
+		"error":    true,
+		"Fatal":    true,
+		"CRITICAL": true,
+		"WARN":     false,
+		"INFO":     false,
+		"ERR":      true,
+		"verbose":  false,
+		"":         false,
+	}
+
+	for level, want := range testCases {
+		if got := IsErrorLevel(level); got != want {
+			t.Errorf("IsErrorLevel(%q) = %v, want %v", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidSeverity is returned when a log level is not a known severity or alias.
+var ErrInvalidSeverity = errors.New("invalid log level")
+
+// Severity is the normalized level of a log. Its values are the severity
+// numbers of the OpenTelemetry log data model, so that a higher value is
+// more severe and levels can be compared.
+type Severity int
+
+const (
+	// SeverityUnspecified is the severity of the logs stored with an unknown
+	// level before levels were validated.
+	SeverityUnspecified Severity = 0
+	SeverityTrace       Severity = 1
+	SeverityDebug       Severity = 5
+	SeverityInfo        Severity = 9
+	SeverityWarn        Severity = 13
+	SeverityError       Severity = 17
+	SeverityFatal       Severity = 21
+)
+
+// severityNames are the canonical names under which levels are stored.
+var severityNames = map[Severity]string{
+	SeverityTrace: "TRACE",
+	SeverityDebug: "DEBUG",
+	SeverityInfo:  "INFO",
+	SeverityWarn:  "WARN",
+	SeverityError: "ERROR",
+	SeverityFatal: "FATAL",
+}
+
+// severityAliases maps the upper-cased names accepted on ingest, including
+// the syslog levels, to their severity. The severity_number column of the
+// logs table applies the same mapping to the stored levels.
+var severityAliases = map[string]Severity{
+	"TRACE":         SeverityTrace,
+	"DEBUG":         SeverityDebug,
+	"INFO":          SeverityInfo,
+	"INFORMATION":   SeverityInfo,
+	"INFORMATIONAL": SeverityInfo,
+	"NOTICE":        SeverityInfo,
+	"WARN":          SeverityWarn,
+	"WARNING":       SeverityWarn,
+	"ERROR":         SeverityError,
+	"ERR":           SeverityError,
+	"FATAL":         SeverityFatal,
+	"CRITICAL":      SeverityFatal,
+	"CRIT":          SeverityFatal,
+	"ALERT":         SeverityFatal,
+	"EMERGENCY":     SeverityFatal,
+	"EMERG":         SeverityFatal,
+	"PANIC":         SeverityFatal,
+}
+
+// ParseSeverity returns the severity of a log level such as "info",
+// "Warning" or "ERR". Surrounding spaces and case are ignored.
+// It returns SeverityUnspecified and ErrInvalidSeverity for unknown levels.
+func ParseSeverity(level string) (Severity, error) {
+	severity, ok := severityAliases[strings.ToUpper(strings.TrimSpace(level))]
+	if !ok {
+		return SeverityUnspecified, ErrInvalidSeverity
+	}
+	return severity, nil
+}
+
+// String returns the canonical name of the severity, such as "WARN".
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "UNSPECIFIED"
+}
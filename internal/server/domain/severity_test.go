@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		want    Severity
+		wantErr error
+	}{
+		"INFO":       {want: SeverityInfo},
+		"info":       {want: SeverityInfo},
+		" Notice ":   {want: SeverityInfo},
+		"debug":      {want: SeverityDebug},
+		"trace":      {want: SeverityTrace},
+		"Warning":    {want: SeverityWarn},
+		"ERR":        {want: SeverityError},
+		"critical":   {want: SeverityFatal},
+		"emerg":      {want: SeverityFatal},
+		"":           {wantErr: ErrInvalidSeverity},
+		"verbose":    {wantErr: ErrInvalidSeverity},
+		"INFO DEBUG": {wantErr: ErrInvalidSeverity},
+	}
+
+	for level, tc := range testCases {
+		t.Run(level, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSeverity(level)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ParseSeverity(%q) error = %v, want %v", level, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseSeverity(%q) = %v, want %v", level, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeverityOrder(t *testing.T) {
+	t.Parallel()
+	ordered := []Severity{SeverityUnspecified, SeverityTrace, SeverityDebug, SeverityInfo, SeverityWarn, SeverityError, SeverityFatal}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1] >= ordered[i] {
+			t.Errorf("%v is not less severe than %v", ordered[i-1], ordered[i])
+		}
+	}
+	if got := SeverityWarn.String(); got != "WARN" {
+		t.Errorf("SeverityWarn.String() = %q, want %q", got, "WARN")
+	}
+}
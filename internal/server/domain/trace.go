@@ -0,0 +1,48 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrTraceNotFound is returned when no log belongs to the requested trace.
+	ErrTraceNotFound = errors.New("trace not found")
+	// ErrInvalidTraceID is returned when a trace ID is not 32 lowercase hex
+	// digits or is all zeros.
+	ErrInvalidTraceID = errors.New("invalid trace ID")
+	// ErrInvalidSpanID is returned when a span ID is not 16 lowercase hex
+	// digits or is all zeros.
+	ErrInvalidSpanID = errors.New("invalid span ID")
+)
+
+// ValidateTraceID checks that id is a valid W3C Trace Context trace ID.
+func ValidateTraceID(id string) error {
+	if !isHexID(id, 32) {
+		return ErrInvalidTraceID
+	}
+	return nil
+}
+
+// ValidateSpanID checks that id is a valid W3C Trace Context span ID.
+func ValidateSpanID(id string) error {
+	if !isHexID(id, 16) {
+		return ErrInvalidSpanID
+	}
+	return nil
+}
+
+// isHexID reports whether id is made of n lowercase hex digits, not all zeros.
+func isHexID(id string, n int) bool {
+	if len(id) != n {
+		return false
+	}
+	zero := true
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+		if c != '0' {
+			zero = false
+		}
+	}
+	return !zero
+}
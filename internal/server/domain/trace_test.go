@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTraceID(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]error{
+		"4bf92f3577b34da6a3ce929d0e0e4736":  nil,
+		"00000000000000000000000000000000":  ErrInvalidTraceID,
+		"4BF92F3577B34DA6A3CE929D0E0E4736":  ErrInvalidTraceID,
+		"4bf92f3577b34da6a3ce929d0e0e473":   ErrInvalidTraceID,
+		"4bf92f3577b34da6a3ce929d0e0e47360": ErrInvalidTraceID,
+		"4bf92f3577b34da6a3ce929d0e0e473g":  ErrInvalidTraceID,
+	}
+	for id, want := range testCases {
+		if err := ValidateTraceID(id); !errors.Is(err, want) {
+			t.Errorf("ValidateTraceID(%q) = %v, want %v", id, err, want)
+		}
+	}
+}
+
+func TestValidateSpanID(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]error{
+		"00f067aa0ba902b7":  nil,
+		"0000000000000000":  ErrInvalidSpanID,
+		"00f067aa0ba902b":   ErrInvalidSpanID,
+		"00f067aa0ba902b7a": ErrInvalidSpanID,
+		"":                  ErrInvalidSpanID,
+	}
+	for id, want := range testCases {
+		if err := ValidateSpanID(id); !errors.Is(err, want) {
+			t.Errorf("ValidateSpanID(%q) = %v, want %v", id, err, want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Package batch buffers log entries in memory and stores them in the
+// underlying repository with multi-row inserts.
+package batch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// DefaultMaxSize is the default number of entries that triggers a flush.
+	DefaultMaxSize = 100
+	// DefaultMaxDelay is the default time an entry may wait before being flushed.
+	DefaultMaxDelay = 50 * time.Millisecond
+	// DefaultFlushTimeout is the default time given to the repository to
+	// store a batch.
+	DefaultFlushTimeout = 10 * time.Second
+	// MaxBatchSize is the largest batch whose multi-row INSERT stays within
+	// the 65,535 placeholders of a MySQL prepared statement, a row of the
+	// logs table taking 11 of them.
+	MaxBatchSize = 65535 / 11
+)
+
+// ErrClosed is returned when an entry is saved after the LogWriter was closed.
+var ErrClosed = errors.New("batch: log writer is closed")
+
+// Config controls when buffered entries are flushed.
+type Config struct {
+	// MaxSize is the number of buffered entries that triggers a flush.
+	MaxSize int
+	// MaxDelay is the longest time an entry waits in the buffer before it is flushed.
+	MaxDelay time.Duration
+	// FlushTimeout bounds the time the repository takes to store a batch.
+	// It defaults to DefaultFlushTimeout.
+	FlushTimeout time.Duration
+}
+
+// NewConfigFromEnv reads the configuration from LOG_BATCH_SIZE,
+// LOG_BATCH_DELAY and LOG_BATCH_FLUSH_TIMEOUT, falling back to the defaults
+// for unset or invalid values. LOG_BATCH_SIZE is capped at MaxBatchSize.
+func NewConfigFromEnv() Config {
+	cfg := Config{
+		MaxSize:      DefaultMaxSize,
+		MaxDelay:     DefaultMaxDelay,
+		FlushTimeout: DefaultFlushTimeout,
+	}
+	if size, err := strconv.Atoi(os.Getenv("LOG_BATCH_SIZE")); err == nil && size > 0 {
+		if size > MaxBatchSize {
+			log.Printf("Capping LOG_BATCH_SIZE %d to %d", size, MaxBatchSize)
+			size = MaxBatchSize
+		}
+		cfg.MaxSize = size
+	}
+	if delay, err := time.ParseDuration(os.Getenv("LOG_BATCH_DELAY")); err == nil && delay > 0 {
+		cfg.MaxDelay = delay
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("LOG_BATCH_FLUSH_TIMEOUT")); err == nil && timeout > 0 {
+		cfg.FlushTimeout = timeout
+	}
+	return cfg
+}
+
+// LogWriter is a domain.ILogRepository that groups the entries passed to
+// Save and CTRSave into batches before storing them.
+//
+// Save and CTRSave block until the batch containing the entry has been
+// committed and return the result of that commit, so callers may acknowledge
+// the entry as soon as they return without error. Once an entry is buffered,
+// they wait for its commit even if their context is done, since the entry is
+// stored regardless. A failed batch is retried
+// in smaller batches until the failing entries are isolated, so that they do
+// not fail the other entries of their batch. All other methods are
+// delegated to the underlying repository.
+type LogWriter struct {
+	domain.ILogRepository
+	logs    *buffer[*domain.Log]
+	ctrLogs *buffer[*domain.CTRLog]
+}
+
+// NewLogWriter creates a LogWriter storing batches in repo and starts
+// flushing in the background. Close must be called to flush the remaining entries.
+func NewLogWriter(repo domain.ILogRepository, cfg Config) *LogWriter {
+	return &LogWriter{
+		ILogRepository: repo,
+		logs:           newBuffer(cfg, repo.SaveBatch),
+		ctrLogs:        newBuffer(cfg, repo.CTRSaveBatch),
+	}
+}
+
+// Save adds log to the current batch and waits until the batch is committed.
+func (w *LogWriter) Save(ctx context.Context, log *domain.Log) error {
+	return w.logs.add(ctx, log)
+}
+
+// CTRSave adds ctrLog to the current batch and waits until the batch is committed.
+func (w *LogWriter) CTRSave(ctx context.Context, ctrLog *domain.CTRLog) error {
+	return w.ctrLogs.add(ctx, ctrLog)
+}
+
+// Close flushes the buffered entries and stops the background flushing.
+// Entries saved after Close fail with ErrClosed.
+func (w *LogWriter) Close() error {
+	w.logs.close()
+	w.ctrLogs.close()
+	return nil
+}
+
+// request is an entry waiting in a buffer together with the channel on which
+// the result of its batch is reported.
+type request[T any] struct {
+	item T
+	done chan error
+}
+
+// buffer accumulates entries of type T and passes them to flush in batches.
+type buffer[T any] struct {
+	cfg      Config
+	flush    func(ctx context.Context, items []T) error
+	requests chan request[T]
+	stopped  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newBuffer[T any](cfg Config, flush func(ctx context.Context, items []T) error) *buffer[T] {
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = DefaultFlushTimeout
+	}
+	b := &buffer[T]{
+		cfg:      cfg,
+		flush:    flush,
+		requests: make(chan request[T], cfg.MaxSize),
+		stopped:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+func (b *buffer[T]) add(ctx context.Context, item T) error {
+	req := request[T]{item: item, done: make(chan error, 1)}
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	select {
+	case b.requests <- req:
+	case <-ctx.Done():
+		b.mu.RUnlock()
+		return ctx.Err()
+	}
+	b.mu.RUnlock()
+
+	// The entry is committed even if ctx is done by now, so returning
+	// ctx.Err() would make the caller retry an entry that is stored. The wait
+	// is bounded by MaxDelay and FlushTimeout.
+	return <-req.done
+}
+
+func (b *buffer[T]) close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.requests)
+	}
+	b.mu.Unlock()
+	<-b.stopped
+}
+
+func (b *buffer[T]) run() {
+	defer close(b.stopped)
+
+	timer := time.NewTimer(b.cfg.MaxDelay)
+	timer.Stop()
+
+	var pending []request[T]
+	commit := func() {
+		timer.Stop()
+		if len(pending) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), b.cfg.FlushTimeout)
+		b.commit(ctx, pending)
+		cancel()
+		pending = nil
+	}
+
+	for {
+		select {
+		case req, ok := <-b.requests:
+			if !ok {
+				commit()
+				return
+			}
+			pending = append(pending, req)
+			if len(pending) == 1 {
+				timer.Reset(b.cfg.MaxDelay)
+			}
+			if len(pending) >= b.cfg.MaxSize {
+				commit()
+			}
+		case <-timer.C:
+			commit()
+		}
+	}
+}
+
+// commit flushes the entries of requests and reports the result to each of
+// them. When a batch fails, it is split in halves that are flushed on their
+// own, so that an invalid entry only fails its own request rather than every
+// entry batched with it.
+func (b *buffer[T]) commit(ctx context.Context, requests []request[T]) {
+	items := make([]T, len(requests))
+	for i, req := range requests {
+		items[i] = req.item
+	}
+	err := b.flush(ctx, items)
+	if err == nil || len(requests) == 1 || ctx.Err() != nil {
+		for _, req := range requests {
+			req.done <- err
+		}
+		return
+	}
+
+	half := len(requests) / 2
+	b.commit(ctx, requests[:half])
+	b.commit(ctx, requests[half:])
+}
@@ -0,0 +1,302 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/presentation"
+	"log_service/internal/server/usecase"
+)
+
+// recordingAcknowledger records whether a delivery was acknowledged.
+type recordingAcknowledger struct {
+	acked  atomic.Bool
+	nacked atomic.Bool
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked.Store(true)
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked.Store(true)
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestNewConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		size string
+		want int
+	}{
+		{name: "unset", size: "", want: DefaultMaxSize},
+		{name: "invalid", size: "many", want: DefaultMaxSize},
+		{name: "valid", size: "500", want: 500},
+		{name: "above the placeholder limit", size: "10000", want: MaxBatchSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_BATCH_SIZE", tt.size)
+			if got := NewConfigFromEnv().MaxSize; got != tt.want {
+				t.Errorf("NewConfigFromEnv().MaxSize = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogWriterFlushBySize(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	mockRepo.EXPECT().SaveBatch(gomock.Any(), gomock.Len(3)).Return(nil).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 3, MaxDelay: time.Hour})
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.Save(context.Background(), &domain.Log{ID: "log-id"}); err != nil {
+				t.Errorf("Save() unexpected error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLogWriterFlushByDelay(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	mockRepo.EXPECT().CTRSaveBatch(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 100, MaxDelay: 10 * time.Millisecond})
+	defer w.Close()
+
+	if err := w.CTRSave(context.Background(), &domain.CTRLog{ID: "ctr-log-id"}); err != nil {
+		t.Errorf("CTRSave() unexpected error = %v", err)
+	}
+}
+
+func TestLogWriterFlushError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	wantErr := errors.New("failed to insert logs")
+	// The batch is split until the error is isolated to each entry.
+	mockRepo.EXPECT().SaveBatch(gomock.Any(), gomock.Any()).Return(wantErr).Times(3)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 2, MaxDelay: time.Hour})
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.Save(context.Background(), &domain.Log{ID: "log-id"}); !errors.Is(err, wantErr) {
+				t.Errorf("Save() expected error %v, got %v", wantErr, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLogWriterIsolatesInvalidEntry(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	wantErr := errors.New("Error 1366: Incorrect string value")
+
+	var mu sync.Mutex
+	committed := make(map[string]bool)
+	mockRepo.EXPECT().CTRSaveBatch(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ctrLogs []*domain.CTRLog) error {
+			for _, ctrLog := range ctrLogs {
+				if ctrLog.ID == "bad" {
+					return wantErr
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, ctrLog := range ctrLogs {
+				committed[ctrLog.ID] = true
+			}
+			return nil
+		}).AnyTimes()
+
+	ids := []string{"ok-1", "ok-2", "bad", "ok-3", "ok-4"}
+	w := NewLogWriter(mockRepo, Config{MaxSize: len(ids), MaxDelay: time.Hour})
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := w.CTRSave(context.Background(), &domain.CTRLog{ID: id})
+			if id == "bad" {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("CTRSave(%s) expected error %v, got %v", id, wantErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("CTRSave(%s) unexpected error = %v", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if want := id != "bad"; committed[id] != want {
+			t.Errorf("entry %s committed = %v, want %v", id, committed[id], want)
+		}
+	}
+}
+
+func TestLogWriterWaitsForCommitAfterCancel(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo.EXPECT().SaveBatch(gomock.Any(), gomock.Len(1)).DoAndReturn(
+		func(context.Context, []*domain.Log) error {
+			// The caller gives up while its entry is being stored.
+			cancel()
+			return nil
+		}).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 1, MaxDelay: time.Hour})
+	defer w.Close()
+
+	if err := w.Save(ctx, &domain.Log{ID: "log-id"}); err != nil {
+		t.Errorf("Save() unexpected error = %v, want the result of the commit", err)
+	}
+}
+
+func TestLogWriterFlushTimeout(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	mockRepo.EXPECT().SaveBatch(gomock.Any(), gomock.Len(1)).DoAndReturn(
+		func(ctx context.Context, _ []*domain.Log) error {
+			// A hung database only returns once the context is done.
+			<-ctx.Done()
+			return ctx.Err()
+		}).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 1, MaxDelay: time.Hour, FlushTimeout: 10 * time.Millisecond})
+	defer w.Close()
+
+	if err := w.Save(context.Background(), &domain.Log{ID: "log-id"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Save() expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestLogWriterClose(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	mockRepo.EXPECT().SaveBatch(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 100, MaxDelay: time.Hour})
+
+	saved := make(chan error, 1)
+	go func() {
+		saved <- w.Save(context.Background(), &domain.Log{ID: "log-id"})
+	}()
+
+	// Wait until the entry is buffered, then close to force the flush.
+	time.Sleep(10 * time.Millisecond)
+	w.Close()
+
+	if err := <-saved; err != nil {
+		t.Errorf("Save() unexpected error = %v", err)
+	}
+	if err := w.Save(context.Background(), &domain.Log{ID: "log-id"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Save() after Close expected error %v, got %v", ErrClosed, err)
+	}
+}
+
+// TestNoAckBeforeCommit runs CTR deliveries through the AMQP handler and
+// checks that none of them is acknowledged before its batch is committed.
+func TestNoAckBeforeCommit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+
+	commit := make(chan struct{})
+	var committed atomic.Bool
+	mockRepo.EXPECT().CTRSaveBatch(gomock.Any(), gomock.Len(3)).DoAndReturn(
+		func(context.Context, []*domain.CTRLog) error {
+			<-commit
+			committed.Store(true)
+			return nil
+		}).Times(1)
+
+	w := NewLogWriter(mockRepo, Config{MaxSize: 3, MaxDelay: time.Hour})
+	defer w.Close()
+	handler := presentation.NewAMQPCTRLogHandler(usecase.NewInsertCTRLogUseCase(w), &amqp.Channel{}, presentation.RequestValidator{}, nil, presentation.RetryPolicy{})
+
+	var wg sync.WaitGroup
+	acknowledgers := make([]*recordingAcknowledger, 3)
+	for i := range acknowledgers {
+		acknowledgers[i] = &recordingAcknowledger{}
+		msg := testCTRDelivery(t, acknowledgers[i])
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler.HandleCTRLog(msg)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	for i, a := range acknowledgers {
+		if a.acked.Load() || a.nacked.Load() {
+			t.Errorf("delivery %d was settled before its batch was committed", i)
+		}
+	}
+
+	close(commit)
+	wg.Wait()
+
+	if !committed.Load() {
+		t.Fatalf("batch was never committed")
+	}
+	for i, a := range acknowledgers {
+		if !a.acked.Load() {
+			t.Errorf("delivery %d was not acknowledged after its batch was committed", i)
+		}
+	}
+}
+
+func testCTRDelivery(t *testing.T, acknowledger amqp.Acknowledger) amqp.Delivery {
+	var payload bytes.Buffer
+	err := json.NewEncoder(&payload).Encode(presentation.AMQPCTRLogRequest{
+		EventType: "click",
+		ObjectID:  "1234",
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amqp.Delivery{Acknowledger: acknowledger, Body: payload.Bytes()}
+}
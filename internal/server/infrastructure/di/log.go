@@ -1,9 +1,12 @@
 package di
 
 import (
+	"database/sql"
+
 	"go.uber.org/dig"
 
 	"log_service/internal/server/domain"
+	"log_service/internal/server/infrastructure/batch"
 	"log_service/internal/server/infrastructure/mysql/db"
 	"log_service/internal/server/infrastructure/mysql/repository"
 	"log_service/internal/server/infrastructure/rabbitmq"
@@ -17,7 +20,20 @@ func BuildLogContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
-	if err := container.Provide(repository.NewLogRepository, dig.As(new(domain.ILogRepository))); err != nil {
+	if err := container.Provide(repository.NewLogRepository); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(newLogWriter); err != nil {
+		return nil, err
+	}
+
+	// Writes go through the batching writer, which delegates reads to the repository.
+	if err := container.Provide(func(w *batch.LogWriter) domain.ILogRepository { return w }); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewLogHub); err != nil {
 		return nil, err
 	}
 
@@ -29,11 +45,48 @@ func BuildLogContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
+	if err := container.Provide(usecase.NewGetLogUseCase, dig.As(new(usecase.IGetLogUseCase))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewGetTraceUseCase, dig.As(new(usecase.IGetTraceUseCase))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewServiceGraphUseCase, dig.As(new(usecase.IServiceGraphUseCase))); err != nil {
+		return nil, err
+	}
+
 	if err := container.Provide(usecase.NewInsertCTRLogUseCase, dig.As(new(usecase.IInsertCTRLogUseCase))); err != nil {
 		return nil, err
 	}
 
-	if err := container.Provide(rabbitmq.Connect); err != nil {
+	if err := container.Provide(usecase.NewStreamLogsUseCase, dig.As(new(usecase.IStreamLogsUseCase))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewCTRStatsUseCase, dig.As(new(usecase.ICTRStatsUseCase))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewCTRStatsCompactionConfigFromEnv); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewCompactCTRStatsUseCase); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(rabbitmq.NewSupervisorConfigFromEnv); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(rabbitmq.NewSupervisor); err != nil {
+		return nil, err
+	}
+
+	// Replies are published on the channel of the current connection.
+	if err := container.Provide(func(s *rabbitmq.Supervisor) presentation.IAMQPPublisher { return s }); err != nil {
 		return nil, err
 	}
 
@@ -45,9 +98,72 @@ func BuildLogContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
+	if err := container.Provide(presentation.NewHttpTraceHandler); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewHttpServiceGraphHandler); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(rabbitmq.NewCTRRetryPublisher, dig.As(new(presentation.IRetryPublisher))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(rabbitmq.NewLogDeadLetterPublisher, dig.As(new(presentation.IDeadLetterPublisher))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(rabbitmq.NewDeadLetterRepository, dig.As(new(domain.IDeadLetterRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(usecase.NewDeadLetterUseCase, dig.As(new(usecase.IDeadLetterUseCase))); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewHttpDeadLetterHandler); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewAdminConfigFromEnv); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(newHttpHealthHandler); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewRetryPolicyFromEnv); err != nil {
+		return nil, err
+	}
+
 	if err := container.Provide(presentation.NewAMQPCTRLogHandler); err != nil {
 		return nil, err
 	}
 
+	if err := container.Provide(presentation.NewHttpCTRLogHandler); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewCORSConfigFromEnv); err != nil {
+		return nil, err
+	}
+
+	if err := container.Provide(presentation.NewRequestValidatorFromEnv); err != nil {
+		return nil, err
+	}
+
 	return container, nil
 }
+
+func newHttpHealthHandler(dbConn *sql.DB, supervisor *rabbitmq.Supervisor) *presentation.HttpHealthHandler {
+	return presentation.NewHttpHealthHandler(map[string]presentation.HealthCheck{
+		"mysql":    dbConn.PingContext,
+		"rabbitmq": supervisor.Check,
+	})
+}
+
+func newLogWriter(repo *repository.LogRepository) *batch.LogWriter {
+	return batch.NewLogWriter(repo, batch.NewConfigFromEnv())
+}
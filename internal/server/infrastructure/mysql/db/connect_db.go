@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"os"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 func Connect() (*sql.DB, error) {
+	dsn, err := dsnFromEnv()
+	if err != nil {
+		return nil, err
+	}
 	for i := 0; i < 10; i++ {
-		db, err := sql.Open("mysql", os.Getenv("MYSQL_URL"))
+		db, err := sql.Open("mysql", dsn)
 		if err != nil {
 			return nil, err
 		}
@@ -22,3 +26,19 @@ func Connect() (*sql.DB, error) {
 	}
 	return nil, fmt.Errorf("could not connect to database")
 }
+
+// dsnFromEnv returns MYSQL_URL, defaulting its charset to utf8mb4 so that
+// 4-byte characters such as emoji reach the utf8mb4 tables intact.
+func dsnFromEnv() (string, error) {
+	cfg, err := mysql.ParseDSN(os.Getenv("MYSQL_URL"))
+	if err != nil {
+		return "", fmt.Errorf("invalid MYSQL_URL: %w", err)
+	}
+	if _, ok := cfg.Params["charset"]; !ok {
+		if cfg.Params == nil {
+			cfg.Params = make(map[string]string)
+		}
+		cfg.Params["charset"] = "utf8mb4"
+	}
+	return cfg.FormatDSN(), nil
+}
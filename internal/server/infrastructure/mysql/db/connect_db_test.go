@@ -0,0 +1,35 @@
+package db
+
+import "testing"
+
+func TestDSNFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "charset defaults to utf8mb4",
+			url:  "root:password@tcp(db:3306)/example",
+			want: "root:password@tcp(db:3306)/example?charset=utf8mb4",
+		},
+		{
+			name: "charset is kept",
+			url:  "root:password@tcp(db:3306)/example?charset=latin1",
+			want: "root:password@tcp(db:3306)/example?charset=latin1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MYSQL_URL", tt.url)
+			got, err := dsnFromEnv()
+			if err != nil {
+				t.Fatalf("dsnFromEnv() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("dsnFromEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
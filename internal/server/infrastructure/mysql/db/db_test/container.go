@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
 	"time"
 
@@ -72,7 +73,7 @@ func ConnectDB(resource *dockertest.Resource, pool *dockertest.Pool) *sql.DB {
 		if err != nil {
 			return err
 		}
-		db, err = sql.Open("mysql", fmt.Sprintf("%s:%s@(%s:%s)/%s?parseTime=true", username, password, hostname, resource.GetPort("3306/tcp"), dbName))
+		db, err = sql.Open("mysql", fmt.Sprintf("%s:%s@(%s:%s)/%s?parseTime=true&charset=utf8mb4&multiStatements=true", username, password, hostname, resource.GetPort("3306/tcp"), dbName))
 		if err != nil {
 			return err
 		}
@@ -103,3 +104,15 @@ func SetupTestDB(schemaFilePath string) {
 	}
 	sqldef.Run(schema.GeneratorModeMysql, database, sp, options)
 }
+
+// MigrateTestDB executes the statements of a migration file as is.
+// Unlike SetupTestDB, it supports migrations that alter existing tables.
+func MigrateTestDB(db *sql.DB, migrationFilePath string) {
+	migration, err := os.ReadFile(migrationFilePath)
+	if err != nil {
+		log.Fatalf("failed to read migration file: %s", err)
+	}
+	if _, err := db.Exec(string(migration)); err != nil {
+		log.Fatalf("failed to run migration %s: %s", migrationFilePath, err)
+	}
+}
@@ -7,60 +7,152 @@ package dbgen
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 )
 
+const deleteCTRRollupsHour = `-- name: DeleteCTRRollupsHour :execrows
+DELETE FROM ctr_rollups_hour
+WHERE bucket_start < ?
+LIMIT ?
+`
+
+type DeleteCTRRollupsHourParams struct {
+	Before time.Time
+	Limit  int32
+}
+
+func (q *Queries) DeleteCTRRollupsHour(ctx context.Context, arg DeleteCTRRollupsHourParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCTRRollupsHour,
+		arg.Before,
+		arg.Limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCTRRollupsMinute = `-- name: DeleteCTRRollupsMinute :execrows
+DELETE FROM ctr_rollups_minute
+WHERE bucket_start < ?
+LIMIT ?
+`
+
+type DeleteCTRRollupsMinuteParams struct {
+	Before time.Time
+	Limit  int32
+}
+
+func (q *Queries) DeleteCTRRollupsMinute(ctx context.Context, arg DeleteCTRRollupsMinuteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCTRRollupsMinute,
+		arg.Before,
+		arg.Limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getLog = `-- name: GetLog :one
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE id = ?
+`
+
+func (q *Queries) GetLog(ctx context.Context, id string) (Log, error) {
+	row := q.db.QueryRowContext(ctx, getLog, id)
+	var i Log
+	err := row.Scan(
+		&i.ID,
+		&i.LogLevel,
+		&i.SeverityNumber,
+		&i.Date,
+		&i.DestinationService,
+		&i.SourceService,
+		&i.RequestType,
+		&i.Content,
+		&i.Attributes,
+		&i.TraceID,
+		&i.SpanID,
+		&i.ParentSpanID,
+	)
+	return i, err
+}
+
 const insertCTRLog = `-- name: InsertCTRLog :exec
 INSERT INTO ctr_logs (
-  event_type, created_at, object_id
+  id, event_type, created_at, object_id
 ) VALUES (
-  ?, ?, ?
+  ?, ?, ?, ?
 )
 `
 
 type InsertCTRLogParams struct {
+	ID        string
 	EventType string
 	CreatedAt time.Time
 	ObjectID  string
 }
 
 func (q *Queries) InsertCTRLog(ctx context.Context, arg InsertCTRLogParams) error {
-	_, err := q.db.ExecContext(ctx, insertCTRLog, arg.EventType, arg.CreatedAt, arg.ObjectID)
+	_, err := q.db.ExecContext(ctx, insertCTRLog,
+		arg.ID,
+		arg.EventType,
+		arg.CreatedAt,
+		arg.ObjectID,
+	)
 	return err
 }
 
 const insertLog = `-- name: InsertLog :exec
 INSERT INTO logs (
-  log_level, date, destination_service, source_service, request_type, content
+  id, log_level, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
 ) VALUES (
-  ?, ?, ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type InsertLogParams struct {
+	ID                 string
 	LogLevel           string
 	Date               time.Time
 	DestinationService string
 	SourceService      string
 	RequestType        string
 	Content            string
+	Attributes         json.RawMessage
+	TraceID            sql.NullString
+	SpanID             sql.NullString
+	ParentSpanID       sql.NullString
 }
 
 func (q *Queries) InsertLog(ctx context.Context, arg InsertLogParams) error {
 	_, err := q.db.ExecContext(ctx, insertLog,
+		arg.ID,
 		arg.LogLevel,
 		arg.Date,
 		arg.DestinationService,
 		arg.SourceService,
 		arg.RequestType,
 		arg.Content,
+		arg.Attributes,
+		arg.TraceID,
+		arg.SpanID,
+		arg.ParentSpanID,
 	)
 	return err
 }
 
 const listCTRLogs = `-- name: ListCTRLogs :many
 SELECT
-  event_type, created_at, object_id
+  id, event_type, created_at, object_id
 FROM ctr_logs
 `
 
@@ -73,7 +165,68 @@ func (q *Queries) ListCTRLogs(ctx context.Context) ([]CtrLog, error) {
 	var items []CtrLog
 	for rows.Next() {
 		var i CtrLog
-		if err := rows.Scan(&i.EventType, &i.CreatedAt, &i.ObjectID); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.CreatedAt,
+			&i.ObjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCTRRollupsDay = `-- name: ListCTRRollupsDay :many
+SELECT
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_day
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+  AND object_id IN (/*SLICE:object_ids*/?)
+ORDER BY object_id, bucket_start
+`
+
+type ListCTRRollupsDayParams struct {
+	FromDate  time.Time
+	ToDate    time.Time
+	ObjectIds []string
+}
+
+func (q *Queries) ListCTRRollupsDay(ctx context.Context, arg ListCTRRollupsDayParams) ([]CtrRollupsDay, error) {
+	query := listCTRRollupsDay
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.FromDate)
+	queryParams = append(queryParams, arg.ToDate)
+	if len(arg.ObjectIds) > 0 {
+		for _, v := range arg.ObjectIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", strings.Repeat(",?", len(arg.ObjectIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CtrRollupsDay
+	for rows.Next() {
+		var i CtrRollupsDay
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -87,14 +240,573 @@ func (q *Queries) ListCTRLogs(ctx context.Context) ([]CtrLog, error) {
 	return items, nil
 }
 
-const listLogs = `-- name: ListLogs :many
+const listCTRRollupsHour = `-- name: ListCTRRollupsHour :many
 SELECT
-  log_level, date, destination_service, source_service, request_type, content
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_hour
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+  AND object_id IN (/*SLICE:object_ids*/?)
+ORDER BY object_id, bucket_start
+`
+
+type ListCTRRollupsHourParams struct {
+	FromDate  time.Time
+	ToDate    time.Time
+	ObjectIds []string
+}
+
+func (q *Queries) ListCTRRollupsHour(ctx context.Context, arg ListCTRRollupsHourParams) ([]CtrRollupsHour, error) {
+	query := listCTRRollupsHour
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.FromDate)
+	queryParams = append(queryParams, arg.ToDate)
+	if len(arg.ObjectIds) > 0 {
+		for _, v := range arg.ObjectIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", strings.Repeat(",?", len(arg.ObjectIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CtrRollupsHour
+	for rows.Next() {
+		var i CtrRollupsHour
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCTRRollupsMinute = `-- name: ListCTRRollupsMinute :many
+SELECT
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_minute
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+  AND object_id IN (/*SLICE:object_ids*/?)
+ORDER BY object_id, bucket_start
+`
+
+type ListCTRRollupsMinuteParams struct {
+	FromDate  time.Time
+	ToDate    time.Time
+	ObjectIds []string
+}
+
+func (q *Queries) ListCTRRollupsMinute(ctx context.Context, arg ListCTRRollupsMinuteParams) ([]CtrRollupsMinute, error) {
+	query := listCTRRollupsMinute
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.FromDate)
+	queryParams = append(queryParams, arg.ToDate)
+	if len(arg.ObjectIds) > 0 {
+		for _, v := range arg.ObjectIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", strings.Repeat(",?", len(arg.ObjectIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:object_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CtrRollupsMinute
+	for rows.Next() {
+		var i CtrRollupsMinute
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCTRTopObjectsDay = `-- name: ListCTRTopObjectsDay :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_day
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+GROUP BY object_id
+ORDER BY
+  CASE ?
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+`
+
+type ListCTRTopObjectsDayParams struct {
+	FromDate time.Time
+	ToDate   time.Time
+	SortBy   string
+	Limit    int32
+}
+
+type ListCTRTopObjectsDayRow struct {
+	ObjectID    string
+	Impressions int64
+	Clicks      int64
+}
+
+func (q *Queries) ListCTRTopObjectsDay(ctx context.Context, arg ListCTRTopObjectsDayParams) ([]ListCTRTopObjectsDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCTRTopObjectsDay,
+		arg.FromDate,
+		arg.ToDate,
+		arg.SortBy,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCTRTopObjectsDayRow
+	for rows.Next() {
+		var i ListCTRTopObjectsDayRow
+		if err := rows.Scan(
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCTRTopObjectsHour = `-- name: ListCTRTopObjectsHour :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_hour
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+GROUP BY object_id
+ORDER BY
+  CASE ?
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+`
+
+type ListCTRTopObjectsHourParams struct {
+	FromDate time.Time
+	ToDate   time.Time
+	SortBy   string
+	Limit    int32
+}
+
+type ListCTRTopObjectsHourRow struct {
+	ObjectID    string
+	Impressions int64
+	Clicks      int64
+}
+
+func (q *Queries) ListCTRTopObjectsHour(ctx context.Context, arg ListCTRTopObjectsHourParams) ([]ListCTRTopObjectsHourRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCTRTopObjectsHour,
+		arg.FromDate,
+		arg.ToDate,
+		arg.SortBy,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCTRTopObjectsHourRow
+	for rows.Next() {
+		var i ListCTRTopObjectsHourRow
+		if err := rows.Scan(
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCTRTopObjectsMinute = `-- name: ListCTRTopObjectsMinute :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_minute
+WHERE bucket_start >= ?
+  AND bucket_start < ?
+GROUP BY object_id
+ORDER BY
+  CASE ?
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+`
+
+type ListCTRTopObjectsMinuteParams struct {
+	FromDate time.Time
+	ToDate   time.Time
+	SortBy   string
+	Limit    int32
+}
+
+type ListCTRTopObjectsMinuteRow struct {
+	ObjectID    string
+	Impressions int64
+	Clicks      int64
+}
+
+func (q *Queries) ListCTRTopObjectsMinute(ctx context.Context, arg ListCTRTopObjectsMinuteParams) ([]ListCTRTopObjectsMinuteRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCTRTopObjectsMinute,
+		arg.FromDate,
+		arg.ToDate,
+		arg.SortBy,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCTRTopObjectsMinuteRow
+	for rows.Next() {
+		var i ListCTRTopObjectsMinuteRow
+		if err := rows.Scan(
+			&i.ObjectID,
+			&i.Impressions,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLogsAsc = `-- name: ListLogsAsc :many
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE (? IS NULL OR severity_number = ?)
+  AND (? IS NULL OR severity_number >= ?)
+  AND (? IS NULL OR source_service = ?)
+  AND (? IS NULL OR destination_service = ?)
+  AND (? IS NULL OR request_type = ?)
+  AND (? IS NULL OR date >= ?)
+  AND (? IS NULL OR date < ?)
+  AND (? IS NULL OR content LIKE ?)
+  AND (? IS NULL OR JSON_CONTAINS(attributes, ?))
+  AND (? IS NULL OR date > ? OR (date = ? AND id > ?))
+ORDER BY date ASC, id ASC
+LIMIT ?
+`
+
+type ListLogsAscParams struct {
+	SeverityNumber     sql.NullInt16
+	MinSeverityNumber  sql.NullInt16
+	SourceService      sql.NullString
+	DestinationService sql.NullString
+	RequestType        sql.NullString
+	FromDate           sql.NullTime
+	ToDate             sql.NullTime
+	Content            sql.NullString
+	Attributes         interface{}
+	CursorDate         sql.NullTime
+	CursorID           sql.NullString
+	Limit              int32
+}
+
+func (q *Queries) ListLogsAsc(ctx context.Context, arg ListLogsAscParams) ([]Log, error) {
+	rows, err := q.db.QueryContext(ctx, listLogsAsc,
+		arg.SeverityNumber,
+		arg.SeverityNumber,
+		arg.MinSeverityNumber,
+		arg.MinSeverityNumber,
+		arg.SourceService,
+		arg.SourceService,
+		arg.DestinationService,
+		arg.DestinationService,
+		arg.RequestType,
+		arg.RequestType,
+		arg.FromDate,
+		arg.FromDate,
+		arg.ToDate,
+		arg.ToDate,
+		arg.Content,
+		arg.Content,
+		arg.Attributes,
+		arg.Attributes,
+		arg.CursorDate,
+		arg.CursorDate,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Log
+	for rows.Next() {
+		var i Log
+		if err := rows.Scan(
+			&i.ID,
+			&i.LogLevel,
+			&i.SeverityNumber,
+			&i.Date,
+			&i.DestinationService,
+			&i.SourceService,
+			&i.RequestType,
+			&i.Content,
+			&i.Attributes,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentSpanID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLogsDesc = `-- name: ListLogsDesc :many
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE (? IS NULL OR severity_number = ?)
+  AND (? IS NULL OR severity_number >= ?)
+  AND (? IS NULL OR source_service = ?)
+  AND (? IS NULL OR destination_service = ?)
+  AND (? IS NULL OR request_type = ?)
+  AND (? IS NULL OR date >= ?)
+  AND (? IS NULL OR date < ?)
+  AND (? IS NULL OR content LIKE ?)
+  AND (? IS NULL OR JSON_CONTAINS(attributes, ?))
+  AND (? IS NULL OR date < ? OR (date = ? AND id < ?))
+ORDER BY date DESC, id DESC
+LIMIT ?
+`
+
+type ListLogsDescParams struct {
+	SeverityNumber     sql.NullInt16
+	MinSeverityNumber  sql.NullInt16
+	SourceService      sql.NullString
+	DestinationService sql.NullString
+	RequestType        sql.NullString
+	FromDate           sql.NullTime
+	ToDate             sql.NullTime
+	Content            sql.NullString
+	Attributes         interface{}
+	CursorDate         sql.NullTime
+	CursorID           sql.NullString
+	Limit              int32
+}
+
+func (q *Queries) ListLogsDesc(ctx context.Context, arg ListLogsDescParams) ([]Log, error) {
+	rows, err := q.db.QueryContext(ctx, listLogsDesc,
+		arg.SeverityNumber,
+		arg.SeverityNumber,
+		arg.MinSeverityNumber,
+		arg.MinSeverityNumber,
+		arg.SourceService,
+		arg.SourceService,
+		arg.DestinationService,
+		arg.DestinationService,
+		arg.RequestType,
+		arg.RequestType,
+		arg.FromDate,
+		arg.FromDate,
+		arg.ToDate,
+		arg.ToDate,
+		arg.Content,
+		arg.Content,
+		arg.Attributes,
+		arg.Attributes,
+		arg.CursorDate,
+		arg.CursorDate,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Log
+	for rows.Next() {
+		var i Log
+		if err := rows.Scan(
+			&i.ID,
+			&i.LogLevel,
+			&i.SeverityNumber,
+			&i.Date,
+			&i.DestinationService,
+			&i.SourceService,
+			&i.RequestType,
+			&i.Content,
+			&i.Attributes,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentSpanID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listServiceCallCounts = `-- name: ListServiceCallCounts :many
+SELECT
+  source_service, destination_service, log_level, COUNT(*) AS count
 FROM logs
+WHERE date >= ?
+  AND date < ?
+  AND source_service <> ''
+  AND destination_service <> ''
+GROUP BY source_service, destination_service, log_level
+ORDER BY source_service, destination_service, log_level
 `
 
-func (q *Queries) ListLogs(ctx context.Context) ([]Log, error) {
-	rows, err := q.db.QueryContext(ctx, listLogs)
+type ListServiceCallCountsParams struct {
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+type ListServiceCallCountsRow struct {
+	SourceService      string
+	DestinationService string
+	LogLevel           string
+	Count              int64
+}
+
+func (q *Queries) ListServiceCallCounts(ctx context.Context, arg ListServiceCallCountsParams) ([]ListServiceCallCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listServiceCallCounts,
+		arg.FromDate,
+		arg.ToDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListServiceCallCountsRow
+	for rows.Next() {
+		var i ListServiceCallCountsRow
+		if err := rows.Scan(
+			&i.SourceService,
+			&i.DestinationService,
+			&i.LogLevel,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTraceLogs = `-- name: ListTraceLogs :many
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE trace_id = ?
+ORDER BY date ASC, id ASC
+LIMIT ?
+`
+
+type ListTraceLogsParams struct {
+	TraceID sql.NullString
+	Limit   int32
+}
+
+func (q *Queries) ListTraceLogs(ctx context.Context, arg ListTraceLogsParams) ([]Log, error) {
+	rows, err := q.db.QueryContext(ctx, listTraceLogs,
+		arg.TraceID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -103,12 +815,18 @@ func (q *Queries) ListLogs(ctx context.Context) ([]Log, error) {
 	for rows.Next() {
 		var i Log
 		if err := rows.Scan(
+			&i.ID,
 			&i.LogLevel,
+			&i.SeverityNumber,
 			&i.Date,
 			&i.DestinationService,
 			&i.SourceService,
 			&i.RequestType,
 			&i.Content,
+			&i.Attributes,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentSpanID,
 		); err != nil {
 			return nil, err
 		}
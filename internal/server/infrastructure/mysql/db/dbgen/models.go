@@ -5,10 +5,14 @@
 package dbgen
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type CtrLog struct {
+	// ID
+	ID string
 	// Event_Type
 	EventType string
 	// Created_At
@@ -17,9 +21,46 @@ type CtrLog struct {
 	ObjectID string
 }
 
+type CtrRollupsDay struct {
+	// Bucket_Start
+	BucketStart time.Time
+	// Object_ID
+	ObjectID string
+	// Impressions
+	Impressions int64
+	// Clicks
+	Clicks int64
+}
+
+type CtrRollupsHour struct {
+	// Bucket_Start
+	BucketStart time.Time
+	// Object_ID
+	ObjectID string
+	// Impressions
+	Impressions int64
+	// Clicks
+	Clicks int64
+}
+
+type CtrRollupsMinute struct {
+	// Bucket_Start
+	BucketStart time.Time
+	// Object_ID
+	ObjectID string
+	// Impressions
+	Impressions int64
+	// Clicks
+	Clicks int64
+}
+
 type Log struct {
+	// ID
+	ID string
 	// Log_Level
 	LogLevel string
+	// Severity_Number
+	SeverityNumber int8
 	// Date
 	Date time.Time
 	// Destination_Service
@@ -30,4 +71,12 @@ type Log struct {
 	RequestType string
 	// Content
 	Content string
+	// Attributes
+	Attributes json.RawMessage
+	// Trace_ID
+	TraceID sql.NullString
+	// Span_ID
+	SpanID sql.NullString
+	// Parent_Span_ID
+	ParentSpanID sql.NullString
 }
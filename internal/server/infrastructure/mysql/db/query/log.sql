@@ -1,25 +1,186 @@
 -- name: InsertLog :exec
 INSERT INTO logs (
-  log_level, date, destination_service, source_service, request_type, content
+  id, log_level, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
 ) VALUES (
-  ?, ?, ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
--- name: ListLogs :many
+-- name: ListLogsAsc :many
 SELECT
-  log_level, date, destination_service, source_service, request_type, content
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
 FROM logs
+WHERE (sqlc.narg('severity_number') IS NULL OR severity_number = sqlc.narg('severity_number'))
+  AND (sqlc.narg('min_severity_number') IS NULL OR severity_number >= sqlc.narg('min_severity_number'))
+  AND (sqlc.narg('source_service') IS NULL OR source_service = sqlc.narg('source_service'))
+  AND (sqlc.narg('destination_service') IS NULL OR destination_service = sqlc.narg('destination_service'))
+  AND (sqlc.narg('request_type') IS NULL OR request_type = sqlc.narg('request_type'))
+  AND (sqlc.narg('from_date') IS NULL OR date >= sqlc.narg('from_date'))
+  AND (sqlc.narg('to_date') IS NULL OR date < sqlc.narg('to_date'))
+  AND (sqlc.narg('content') IS NULL OR content LIKE sqlc.narg('content'))
+  AND (sqlc.narg('attributes') IS NULL OR JSON_CONTAINS(attributes, sqlc.narg('attributes')))
+  AND (sqlc.narg('cursor_date') IS NULL OR date > sqlc.narg('cursor_date') OR (date = sqlc.narg('cursor_date') AND id > sqlc.narg('cursor_id')))
+ORDER BY date ASC, id ASC
+LIMIT ?
+;
+
+-- name: ListLogsDesc :many
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE (sqlc.narg('severity_number') IS NULL OR severity_number = sqlc.narg('severity_number'))
+  AND (sqlc.narg('min_severity_number') IS NULL OR severity_number >= sqlc.narg('min_severity_number'))
+  AND (sqlc.narg('source_service') IS NULL OR source_service = sqlc.narg('source_service'))
+  AND (sqlc.narg('destination_service') IS NULL OR destination_service = sqlc.narg('destination_service'))
+  AND (sqlc.narg('request_type') IS NULL OR request_type = sqlc.narg('request_type'))
+  AND (sqlc.narg('from_date') IS NULL OR date >= sqlc.narg('from_date'))
+  AND (sqlc.narg('to_date') IS NULL OR date < sqlc.narg('to_date'))
+  AND (sqlc.narg('content') IS NULL OR content LIKE sqlc.narg('content'))
+  AND (sqlc.narg('attributes') IS NULL OR JSON_CONTAINS(attributes, sqlc.narg('attributes')))
+  AND (sqlc.narg('cursor_date') IS NULL OR date < sqlc.narg('cursor_date') OR (date = sqlc.narg('cursor_date') AND id < sqlc.narg('cursor_id')))
+ORDER BY date DESC, id DESC
+LIMIT ?
+;
+
+-- name: GetLog :one
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE id = ?
+;
+
+-- name: ListTraceLogs :many
+SELECT
+  id, log_level, severity_number, date, destination_service, source_service, request_type, content, attributes,
+  trace_id, span_id, parent_span_id
+FROM logs
+WHERE trace_id = ?
+ORDER BY date ASC, id ASC
+LIMIT ?
+;
+
+-- name: ListServiceCallCounts :many
+SELECT
+  source_service, destination_service, log_level, COUNT(*) AS count
+FROM logs
+WHERE date >= sqlc.arg('from_date')
+  AND date < sqlc.arg('to_date')
+  AND source_service <> ''
+  AND destination_service <> ''
+GROUP BY source_service, destination_service, log_level
+ORDER BY source_service, destination_service, log_level
 ;
 
 -- name: InsertCTRLog :exec
 INSERT INTO ctr_logs (
-  event_type, created_at, object_id
+  id, event_type, created_at, object_id
 ) VALUES (
-  ?, ?, ?
+  ?, ?, ?, ?
 );
 
 -- name: ListCTRLogs :many
 SELECT
-  event_type, created_at, object_id
+  id, event_type, created_at, object_id
 FROM ctr_logs
-;
\ No newline at end of file
+;
+-- name: ListCTRTopObjectsMinute :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_minute
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+GROUP BY object_id
+ORDER BY
+  CASE sqlc.arg('sort_by')
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+;
+
+-- name: ListCTRRollupsMinute :many
+SELECT
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_minute
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+  AND object_id IN (sqlc.slice('object_ids'))
+ORDER BY object_id, bucket_start
+;
+
+-- name: DeleteCTRRollupsMinute :execrows
+DELETE FROM ctr_rollups_minute
+WHERE bucket_start < sqlc.arg('before')
+LIMIT ?
+;
+
+-- name: ListCTRTopObjectsHour :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_hour
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+GROUP BY object_id
+ORDER BY
+  CASE sqlc.arg('sort_by')
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+;
+
+-- name: ListCTRRollupsHour :many
+SELECT
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_hour
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+  AND object_id IN (sqlc.slice('object_ids'))
+ORDER BY object_id, bucket_start
+;
+
+-- name: DeleteCTRRollupsHour :execrows
+DELETE FROM ctr_rollups_hour
+WHERE bucket_start < sqlc.arg('before')
+LIMIT ?
+;
+
+-- name: ListCTRTopObjectsDay :many
+SELECT
+  object_id,
+  CAST(SUM(impressions) AS SIGNED) AS impressions,
+  CAST(SUM(clicks) AS SIGNED) AS clicks
+FROM ctr_rollups_day
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+GROUP BY object_id
+ORDER BY
+  CASE sqlc.arg('sort_by')
+    WHEN 'clicks' THEN SUM(clicks)
+    WHEN 'ctr' THEN COALESCE(SUM(clicks) * 1e0 / NULLIF(SUM(impressions), 0), 0)
+    ELSE SUM(impressions)
+  END DESC,
+  object_id
+LIMIT ?
+;
+
+-- name: ListCTRRollupsDay :many
+SELECT
+  bucket_start, object_id, impressions, clicks
+FROM ctr_rollups_day
+WHERE bucket_start >= sqlc.arg('from_date')
+  AND bucket_start < sqlc.arg('to_date')
+  AND object_id IN (sqlc.slice('object_ids'))
+ORDER BY object_id, bucket_start
+;
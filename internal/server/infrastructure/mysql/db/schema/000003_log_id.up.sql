@@ -0,0 +1,8 @@
+-- The default only backfills the existing rows, whose IDs are MySQL's UUIDv1
+-- and therefore not time-ordered. New rows get a UUIDv7 from the server, so
+-- the default is dropped to keep any insert from leaving out the ID.
+ALTER TABLE `logs`
+  ADD COLUMN `id` CHAR(36) NOT NULL DEFAULT (UUID()) COMMENT 'ID' FIRST,
+  ADD PRIMARY KEY (`id`);
+ALTER TABLE `logs`
+  ALTER COLUMN `id` DROP DEFAULT;
@@ -0,0 +1,3 @@
+ALTER TABLE `ctr_logs`
+  DROP PRIMARY KEY,
+  DROP COLUMN `id`;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE `logs`
+  DROP INDEX `idx_logs_date_id`,
+  DROP INDEX `idx_logs_log_level_date`,
+  DROP INDEX `idx_logs_source_service_date`,
+  DROP INDEX `idx_logs_destination_service_date`,
+  DROP INDEX `idx_logs_request_type_date`;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE `logs`
+  ADD INDEX `idx_logs_date_id` (`date`, `id`),
+  ADD INDEX `idx_logs_log_level_date` (`log_level`, `date`),
+  ADD INDEX `idx_logs_source_service_date` (`source_service`, `date`),
+  ADD INDEX `idx_logs_destination_service_date` (`destination_service`, `date`),
+  ADD INDEX `idx_logs_request_type_date` (`request_type`, `date`);
\ No newline at end of file
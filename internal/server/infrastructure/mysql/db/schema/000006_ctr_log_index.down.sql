@@ -0,0 +1,2 @@
+ALTER TABLE `ctr_logs`
+  DROP INDEX `idx_ctr_logs_created_at_object_id_event_type`;
@@ -0,0 +1,2 @@
+ALTER TABLE `ctr_logs`
+  ADD INDEX `idx_ctr_logs_created_at_object_id_event_type` (`created_at`, `object_id`, `event_type`);
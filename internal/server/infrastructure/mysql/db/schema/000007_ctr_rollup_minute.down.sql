@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ctr_rollups_minute;
\ No newline at end of file
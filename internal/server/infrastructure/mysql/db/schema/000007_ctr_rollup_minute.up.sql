@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `ctr_rollups_minute` (
+  `bucket_start` DATETIME NOT NULL COMMENT 'Bucket_Start',
+  `object_id` VARCHAR(100) NOT NULL COMMENT 'Object_ID',
+  `impressions` BIGINT NOT NULL DEFAULT 0 COMMENT 'Impressions',
+  `clicks` BIGINT NOT NULL DEFAULT 0 COMMENT 'Clicks',
+  PRIMARY KEY (`bucket_start`, `object_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8
+SELECT
+  CAST(DATE_FORMAT(`created_at`, '%Y-%m-%d %H:%i:00') AS DATETIME) AS `bucket_start`,
+  `object_id`,
+  CAST(SUM(`event_type` = 'impression') AS SIGNED) AS `impressions`,
+  CAST(SUM(`event_type` = 'click') AS SIGNED) AS `clicks`
+FROM `ctr_logs`
+WHERE `event_type` IN ('impression', 'click')
+GROUP BY `bucket_start`, `object_id`;
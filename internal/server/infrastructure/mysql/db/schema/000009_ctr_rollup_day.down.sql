@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ctr_rollups_day;
\ No newline at end of file
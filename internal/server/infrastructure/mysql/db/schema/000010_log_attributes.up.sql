@@ -0,0 +1,2 @@
+ALTER TABLE `logs`
+  ADD COLUMN `attributes` JSON NULL COMMENT 'Attributes' AFTER `content`;
@@ -0,0 +1,5 @@
+ALTER TABLE `logs`
+  DROP INDEX `idx_logs_trace_id_date`,
+  DROP COLUMN `parent_span_id`,
+  DROP COLUMN `span_id`,
+  DROP COLUMN `trace_id`;
@@ -0,0 +1,5 @@
+ALTER TABLE `logs`
+  ADD COLUMN `trace_id` CHAR(32) NULL COMMENT 'Trace_ID' AFTER `attributes`,
+  ADD COLUMN `span_id` CHAR(16) NULL COMMENT 'Span_ID' AFTER `trace_id`,
+  ADD COLUMN `parent_span_id` CHAR(16) NULL COMMENT 'Parent_Span_ID' AFTER `span_id`,
+  ADD INDEX `idx_logs_trace_id_date` (`trace_id`, `date`, `id`);
@@ -0,0 +1,3 @@
+ALTER TABLE `logs`
+  DROP INDEX `idx_logs_severity_number_date`,
+  DROP COLUMN `severity_number`;
@@ -0,0 +1,24 @@
+ALTER TABLE `logs`
+  ADD COLUMN `severity_number` TINYINT AS (
+    CASE UPPER(TRIM(`log_level`))
+      WHEN 'TRACE' THEN 1
+      WHEN 'DEBUG' THEN 5
+      WHEN 'INFO' THEN 9
+      WHEN 'INFORMATION' THEN 9
+      WHEN 'INFORMATIONAL' THEN 9
+      WHEN 'NOTICE' THEN 9
+      WHEN 'WARN' THEN 13
+      WHEN 'WARNING' THEN 13
+      WHEN 'ERROR' THEN 17
+      WHEN 'ERR' THEN 17
+      WHEN 'FATAL' THEN 21
+      WHEN 'CRITICAL' THEN 21
+      WHEN 'CRIT' THEN 21
+      WHEN 'ALERT' THEN 21
+      WHEN 'EMERGENCY' THEN 21
+      WHEN 'EMERG' THEN 21
+      WHEN 'PANIC' THEN 21
+      ELSE 0
+    END
+  ) STORED NOT NULL COMMENT 'Severity_Number' AFTER `log_level`,
+  ADD INDEX `idx_logs_severity_number_date` (`severity_number`, `date`);
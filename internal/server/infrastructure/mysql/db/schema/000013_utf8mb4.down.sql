@@ -0,0 +1,5 @@
+ALTER TABLE `logs` CONVERT TO CHARACTER SET utf8mb3 COLLATE utf8mb3_general_ci;
+ALTER TABLE `ctr_logs` CONVERT TO CHARACTER SET utf8mb3 COLLATE utf8mb3_general_ci;
+ALTER TABLE `ctr_rollups_minute` CONVERT TO CHARACTER SET utf8mb3 COLLATE utf8mb3_general_ci;
+ALTER TABLE `ctr_rollups_hour` CONVERT TO CHARACTER SET utf8mb3 COLLATE utf8mb3_general_ci;
+ALTER TABLE `ctr_rollups_day` CONVERT TO CHARACTER SET utf8mb3 COLLATE utf8mb3_general_ci;
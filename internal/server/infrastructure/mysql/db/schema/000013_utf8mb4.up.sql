@@ -0,0 +1,5 @@
+ALTER TABLE `logs` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
+ALTER TABLE `ctr_logs` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
+ALTER TABLE `ctr_rollups_minute` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
+ALTER TABLE `ctr_rollups_hour` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
+ALTER TABLE `ctr_rollups_day` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
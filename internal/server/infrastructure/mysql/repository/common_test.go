@@ -20,6 +20,17 @@ func TestMain(m *testing.M) {
 
 	dbTest.SetupTestDB("../db/schema/000001_log.up.sql")
 	dbTest.SetupTestDB("../db/schema/000002_ctr_log.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000003_log_id.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000004_ctr_log_id.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000005_log_index.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000006_ctr_log_index.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000007_ctr_rollup_minute.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000008_ctr_rollup_hour.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000009_ctr_rollup_day.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000010_log_attributes.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000011_log_trace.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000012_log_severity.up.sql")
+	dbTest.MigrateTestDB(dbConnTest, "../db/schema/000013_utf8mb4.up.sql")
 
 	m.Run()
 }
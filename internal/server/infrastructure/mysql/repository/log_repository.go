@@ -3,11 +3,47 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"log_service/internal/server/domain"
 	"log_service/internal/server/infrastructure/mysql/db/dbgen"
 )
 
+// sqlc cannot generate multi-row INSERT statements for MySQL, so the batch
+// statements are assembled from the same columns as InsertLog and InsertCTRLog.
+const (
+	insertLogsPrefix = "INSERT INTO logs (id, log_level, date, destination_service, source_service, request_type, content, attributes, trace_id, span_id, parent_span_id) VALUES "
+	insertLogsRow    = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	insertCTRLogsPrefix = "INSERT INTO ctr_logs (id, event_type, created_at, object_id) VALUES "
+	insertCTRLogsRow    = "(?, ?, ?, ?)"
+
+	upsertCTRRollupsPrefix = "INSERT INTO %s (bucket_start, object_id, impressions, clicks) VALUES "
+	upsertCTRRollupsRow    = "(?, ?, ?, ?)"
+	upsertCTRRollupsSuffix = " AS new ON DUPLICATE KEY UPDATE impressions = impressions + new.impressions, clicks = clicks + new.clicks"
+)
+
+// ctrRollupTables are the tables holding the CTR counts of each bucket width.
+// Every CTR event is counted in all of them.
+var ctrRollupTables = []struct {
+	bucket domain.CTRBucket
+	table  string
+}{
+	{domain.CTRBucketMinute, "ctr_rollups_minute"},
+	{domain.CTRBucketHour, "ctr_rollups_hour"},
+	{domain.CTRBucketDay, "ctr_rollups_day"},
+}
+
+// deleteCTRRollupsChunk is the number of rows deleted per statement by
+// DeleteCTRStats, so that a single statement does not hold locks for long.
+const deleteCTRRollupsChunk = 10000
+
 // LogRepository provides methods to interact with the log storage in the database.
 type LogRepository struct {
 	db *sql.DB
@@ -23,49 +59,349 @@ func NewLogRepository(db *sql.DB) *LogRepository {
 // Save stores a new log entry into the database.
 // It takes a context and a Log object from the domain package as arguments.
 func (r *LogRepository) Save(ctx context.Context, log *domain.Log) error {
-	err := dbgen.New(r.db).InsertLog(ctx, dbgen.InsertLogParams{
+	attributes, err := marshalAttributes(log.Attributes)
+	if err != nil {
+		return err
+	}
+	err = dbgen.New(r.db).InsertLog(ctx, dbgen.InsertLogParams{
+		ID:                 log.ID,
 		LogLevel:           log.LogLevel,
 		Date:               log.Date,
 		DestinationService: log.DestinationService,
 		SourceService:      log.SourceService,
 		RequestType:        log.RequestType,
 		Content:            log.Content,
+		Attributes:         attributes,
+		TraceID:            nullString(log.TraceID),
+		SpanID:             nullString(log.SpanID),
+		ParentSpanID:       nullString(log.ParentSpanID),
 	})
 	return err
 }
 
-// List retrieves all log entries from the database.
+// SaveBatch stores the given log entries into the database with a single
+// multi-row INSERT, so either all of them are stored or none is.
+func (r *LogRepository) SaveBatch(ctx context.Context, logs []*domain.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(logs)*11)
+	for _, log := range logs {
+		attributes, err := marshalAttributes(log.Attributes)
+		if err != nil {
+			return err
+		}
+		args = append(args,
+			log.ID,
+			log.LogLevel,
+			log.Date,
+			log.DestinationService,
+			log.SourceService,
+			log.RequestType,
+			log.Content,
+			attributes,
+			nullString(log.TraceID),
+			nullString(log.SpanID),
+			nullString(log.ParentSpanID),
+		)
+	}
+	_, err := r.db.ExecContext(ctx, multiRowInsert(insertLogsPrefix, insertLogsRow, len(logs)), args...)
+	return err
+}
+
+// List retrieves the log entries matching the given filter from the database,
+// sorted by date and ID in the requested order.
 // It returns a slice of Log objects from the domain package or an error if the query fails.
-func (r *LogRepository) List(ctx context.Context) ([]domain.Log, error) {
-	logs, err := dbgen.New(r.db).ListLogs(ctx)
+func (r *LogRepository) List(ctx context.Context, filter *domain.LogFilter) ([]domain.Log, error) {
+	params, err := listLogsParams(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []dbgen.Log
+	if filter.Order == domain.SortOrderAsc {
+		logs, err = dbgen.New(r.db).ListLogsAsc(ctx, dbgen.ListLogsAscParams(params))
+	} else {
+		logs, err = dbgen.New(r.db).ListLogsDesc(ctx, params)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	var result []domain.Log
 	for _, log := range logs {
-		result = append(result, domain.Log{
-			LogLevel:           log.LogLevel,
-			Date:               log.Date,
-			DestinationService: log.DestinationService,
-			SourceService:      log.SourceService,
-			RequestType:        log.RequestType,
-			Content:            log.Content,
+		l, err := toDomainLog(log)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *l)
+	}
+
+	return result, nil
+}
+
+// toDomainLog converts a row of the logs table into a domain.Log.
+func toDomainLog(log dbgen.Log) (*domain.Log, error) {
+	var attributes map[string]any
+	if len(log.Attributes) > 0 {
+		if err := json.Unmarshal(log.Attributes, &attributes); err != nil {
+			return nil, fmt.Errorf("invalid attributes of log %s: %w", log.ID, err)
+		}
+	}
+	return &domain.Log{
+		ID:                 log.ID,
+		LogLevel:           log.LogLevel,
+		Date:               log.Date,
+		DestinationService: log.DestinationService,
+		SourceService:      log.SourceService,
+		RequestType:        log.RequestType,
+		Content:            log.Content,
+		Attributes:         attributes,
+		TraceID:            log.TraceID.String,
+		SpanID:             log.SpanID.String,
+		ParentSpanID:       log.ParentSpanID.String,
+	}, nil
+}
+
+// marshalAttributes encodes the attributes of a log as JSON, or as NULL when there are none.
+func marshalAttributes(attributes map[string]any) (json.RawMessage, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(attributes)
+}
+
+// listLogsParams converts a LogFilter into the parameters of the list queries.
+// Both ListLogsAsc and ListLogsDesc take the same parameters.
+func listLogsParams(filter *domain.LogFilter) (dbgen.ListLogsDescParams, error) {
+	params := dbgen.ListLogsDescParams{
+		SeverityNumber:     nullSeverity(filter.Severity),
+		MinSeverityNumber:  nullSeverity(filter.MinSeverity),
+		SourceService:      nullString(filter.SourceService),
+		DestinationService: nullString(filter.DestinationService),
+		RequestType:        nullString(filter.RequestType),
+		FromDate:           nullTime(filter.From),
+		ToDate:             nullTime(filter.To),
+		Limit:              int32(filter.Limit),
+	}
+	if filter.Content != "" {
+		params.Content = nullString("%" + likeEscaper.Replace(filter.Content) + "%")
+	}
+	if len(filter.Attributes) > 0 {
+		// JSON_CONTAINS matches the logs whose attributes include all of these.
+		attributes, err := json.Marshal(filter.Attributes)
+		if err != nil {
+			return dbgen.ListLogsDescParams{}, err
+		}
+		params.Attributes = string(attributes)
+	}
+	if filter.After != nil {
+		params.CursorDate = nullTime(filter.After.Date)
+		params.CursorID = nullString(filter.After.ID)
+	}
+	return params, nil
+}
+
+// multiRowInsert returns an INSERT statement inserting n rows.
+func multiRowInsert(prefix, row string, n int) string {
+	var b strings.Builder
+	b.Grow(len(prefix) + n*(len(row)+2))
+	b.WriteString(prefix)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(row)
+	}
+	return b.String()
+}
+
+// likeEscaper escapes the wildcard characters of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// nullString returns a NULL string for the empty string.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullSeverity returns a NULL severity for domain.SeverityUnspecified.
+func nullSeverity(s domain.Severity) sql.NullInt16 {
+	return sql.NullInt16{Int16: int16(s), Valid: s != domain.SeverityUnspecified}
+}
+
+// nullTime returns a NULL time for the zero time.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// Get retrieves the log entry with the given ID from the database.
+// It returns domain.ErrLogNotFound if no such entry exists.
+func (r *LogRepository) Get(ctx context.Context, id string) (*domain.Log, error) {
+	log, err := dbgen.New(r.db).GetLog(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrLogNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainLog(log)
+}
+
+// ListTrace retrieves at most limit log entries of the given trace from the
+// database, sorted by date and ID.
+func (r *LogRepository) ListTrace(ctx context.Context, traceID string, limit int) ([]domain.Log, error) {
+	logs, err := dbgen.New(r.db).ListTraceLogs(ctx, dbgen.ListTraceLogsParams{
+		TraceID: nullString(traceID),
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.Log
+	for _, log := range logs {
+		l, err := toDomainLog(log)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *l)
+	}
+
+	return result, nil
+}
+
+// ServiceCallCounts counts the logs dated within the filter range per source
+// service, destination service and log level.
+func (r *LogRepository) ServiceCallCounts(ctx context.Context, filter *domain.ServiceGraphFilter) ([]domain.ServiceCallCount, error) {
+	rows, err := dbgen.New(r.db).ListServiceCallCounts(ctx, dbgen.ListServiceCallCountsParams{
+		FromDate: filter.From,
+		ToDate:   filter.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ServiceCallCount, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.ServiceCallCount{
+			SourceService:      row.SourceService,
+			DestinationService: row.DestinationService,
+			LogLevel:           row.LogLevel,
+			Count:              row.Count,
 		})
 	}
 
 	return result, nil
 }
 
-// CTRSave stores a new CTRLog entry into the database.
+// CTRSave stores a new CTRLog entry into the database and adds it to the CTR counts.
 // It takes a context and a CTRLog object from the domain package as arguments.
 func (r *LogRepository) CTRSave(ctx context.Context, ctrLog *domain.CTRLog) error {
-	err := dbgen.New(r.db).InsertCTRLog(ctx, dbgen.InsertCTRLogParams{
-		EventType: ctrLog.EventType,
-		CreatedAt: ctrLog.CreatedAt,
-		ObjectID:  ctrLog.ObjectID,
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		err := dbgen.New(tx).InsertCTRLog(ctx, dbgen.InsertCTRLogParams{
+			ID:        ctrLog.ID,
+			EventType: ctrLog.EventType,
+			CreatedAt: ctrLog.CreatedAt,
+			ObjectID:  ctrLog.ObjectID,
+		})
+		if err != nil {
+			return err
+		}
+		return upsertCTRRollups(ctx, tx, []*domain.CTRLog{ctrLog})
 	})
-	return err
+}
+
+// CTRSaveBatch stores the given CTRLog entries into the database with a single
+// multi-row INSERT and adds them to the CTR counts in the same transaction,
+// so either all of them are stored and counted or none is.
+func (r *LogRepository) CTRSaveBatch(ctx context.Context, ctrLogs []*domain.CTRLog) error {
+	if len(ctrLogs) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(ctrLogs)*4)
+	for _, ctrLog := range ctrLogs {
+		args = append(args,
+			ctrLog.ID,
+			ctrLog.EventType,
+			ctrLog.CreatedAt,
+			ctrLog.ObjectID,
+		)
+	}
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, multiRowInsert(insertCTRLogsPrefix, insertCTRLogsRow, len(ctrLogs)), args...); err != nil {
+			return err
+		}
+		return upsertCTRRollups(ctx, tx, ctrLogs)
+	})
+}
+
+// ctrRollupKey identifies a row of a CTR rollup table.
+type ctrRollupKey struct {
+	bucketStart time.Time
+	objectID    string
+}
+
+// upsertCTRRollups adds the impressions and clicks of ctrLogs to the counts
+// of every rollup table. Events of other types are not counted.
+//
+// The rows are upserted in primary key order so that concurrent batches lock
+// them in the same order and cannot deadlock each other.
+func upsertCTRRollups(ctx context.Context, tx *sql.Tx, ctrLogs []*domain.CTRLog) error {
+	for _, rollup := range ctrRollupTables {
+		counts := make(map[ctrRollupKey]*domain.CTRStat)
+		for _, ctrLog := range ctrLogs {
+			if ctrLog.EventType != domain.CTREventImpression && ctrLog.EventType != domain.CTREventClick {
+				continue
+			}
+			key := ctrRollupKey{bucketStart: rollup.bucket.Truncate(ctrLog.CreatedAt), objectID: ctrLog.ObjectID}
+			count, ok := counts[key]
+			if !ok {
+				count = &domain.CTRStat{ObjectID: key.objectID, BucketStart: key.bucketStart}
+				counts[key] = count
+			}
+			if ctrLog.EventType == domain.CTREventImpression {
+				count.Impressions++
+			} else {
+				count.Clicks++
+			}
+		}
+		if len(counts) == 0 {
+			return nil
+		}
+
+		keys := slices.SortedFunc(maps.Keys(counts), func(a, b ctrRollupKey) int {
+			if c := a.bucketStart.Compare(b.bucketStart); c != 0 {
+				return c
+			}
+			return strings.Compare(a.objectID, b.objectID)
+		})
+		args := make([]any, 0, len(keys)*4)
+		for _, key := range keys {
+			count := counts[key]
+			args = append(args, count.BucketStart, count.ObjectID, count.Impressions, count.Clicks)
+		}
+		query := multiRowInsert(fmt.Sprintf(upsertCTRRollupsPrefix, rollup.table), upsertCTRRollupsRow, len(keys)) + upsertCTRRollupsSuffix
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+func (r *LogRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
 }
 
 // CTRList retrieves all CTRLog entries from the database.
@@ -79,6 +415,7 @@ func (r *LogRepository) CTRList(ctx context.Context) ([]domain.CTRLog, error) {
 	var result []domain.CTRLog
 	for _, ctrLog := range ctrLogs {
 		result = append(result, domain.CTRLog{
+			ID:        ctrLog.ID,
 			EventType: ctrLog.EventType,
 			CreatedAt: ctrLog.CreatedAt,
 			ObjectID:  ctrLog.ObjectID,
@@ -87,3 +424,116 @@ func (r *LogRepository) CTRList(ctx context.Context) ([]domain.CTRLog, error) {
 
 	return result, nil
 }
+
+// CTRTopObjects sums the impressions and clicks of every object over the
+// minute, hour or day rollup table, depending on the bucket of the filter,
+// and ranks the objects in the database so that only the top ones are read.
+func (r *LogRepository) CTRTopObjects(ctx context.Context, filter *domain.CTRStatsFilter, sortBy domain.CTRStatsSort, limit int) ([]domain.CTRTotal, error) {
+	q := dbgen.New(r.db)
+
+	var (
+		rows []dbgen.ListCTRTopObjectsMinuteRow
+		err  error
+	)
+	switch filter.Bucket {
+	case domain.CTRBucketMinute:
+		rows, err = q.ListCTRTopObjectsMinute(ctx, dbgen.ListCTRTopObjectsMinuteParams{FromDate: filter.From, ToDate: filter.To, SortBy: string(sortBy), Limit: int32(limit)})
+	case domain.CTRBucketHour:
+		var hourly []dbgen.ListCTRTopObjectsHourRow
+		hourly, err = q.ListCTRTopObjectsHour(ctx, dbgen.ListCTRTopObjectsHourParams{FromDate: filter.From, ToDate: filter.To, SortBy: string(sortBy), Limit: int32(limit)})
+		for _, row := range hourly {
+			rows = append(rows, dbgen.ListCTRTopObjectsMinuteRow(row))
+		}
+	case domain.CTRBucketDay:
+		var daily []dbgen.ListCTRTopObjectsDayRow
+		daily, err = q.ListCTRTopObjectsDay(ctx, dbgen.ListCTRTopObjectsDayParams{FromDate: filter.From, ToDate: filter.To, SortBy: string(sortBy), Limit: int32(limit)})
+		for _, row := range daily {
+			rows = append(rows, dbgen.ListCTRTopObjectsMinuteRow(row))
+		}
+	default:
+		return nil, fmt.Errorf("unknown CTR bucket %q", filter.Bucket)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CTRTotal, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.CTRTotal(row))
+	}
+
+	return result, nil
+}
+
+// CTRStats reads the impressions and clicks of the objects of the filter per
+// minute, hour or day, depending on the bucket of the filter, from the rollup
+// tables. Only the buckets starting within the range of the filter are
+// returned. The result is sorted by object ID and then by bucket start.
+func (r *LogRepository) CTRStats(ctx context.Context, filter *domain.CTRStatsFilter) ([]domain.CTRStat, error) {
+	q := dbgen.New(r.db)
+
+	var (
+		rows []dbgen.CtrRollupsMinute
+		err  error
+	)
+	switch filter.Bucket {
+	case domain.CTRBucketMinute:
+		rows, err = q.ListCTRRollupsMinute(ctx, dbgen.ListCTRRollupsMinuteParams{FromDate: filter.From, ToDate: filter.To, ObjectIds: filter.ObjectIDs})
+	case domain.CTRBucketHour:
+		var hourly []dbgen.CtrRollupsHour
+		hourly, err = q.ListCTRRollupsHour(ctx, dbgen.ListCTRRollupsHourParams{FromDate: filter.From, ToDate: filter.To, ObjectIds: filter.ObjectIDs})
+		for _, row := range hourly {
+			rows = append(rows, dbgen.CtrRollupsMinute(row))
+		}
+	case domain.CTRBucketDay:
+		var daily []dbgen.CtrRollupsDay
+		daily, err = q.ListCTRRollupsDay(ctx, dbgen.ListCTRRollupsDayParams{FromDate: filter.From, ToDate: filter.To, ObjectIds: filter.ObjectIDs})
+		for _, row := range daily {
+			rows = append(rows, dbgen.CtrRollupsMinute(row))
+		}
+	default:
+		return nil, fmt.Errorf("unknown CTR bucket %q", filter.Bucket)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CTRStat, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.CTRStat{
+			ObjectID:    row.ObjectID,
+			BucketStart: row.BucketStart,
+			Impressions: row.Impressions,
+			Clicks:      row.Clicks,
+		})
+	}
+
+	return result, nil
+}
+
+// DeleteCTRStats deletes the per-minute or per-hour counts of the buckets
+// starting before the given time. The per-day counts are never deleted.
+// Rows are deleted in chunks until none is left.
+func (r *LogRepository) DeleteCTRStats(ctx context.Context, bucket domain.CTRBucket, before time.Time) (int64, error) {
+	q := dbgen.New(r.db)
+
+	var deleted int64
+	for {
+		var (
+			n   int64
+			err error
+		)
+		switch bucket {
+		case domain.CTRBucketMinute:
+			n, err = q.DeleteCTRRollupsMinute(ctx, dbgen.DeleteCTRRollupsMinuteParams{Before: before, Limit: deleteCTRRollupsChunk})
+		case domain.CTRBucketHour:
+			n, err = q.DeleteCTRRollupsHour(ctx, dbgen.DeleteCTRRollupsHourParams{Before: before, Limit: deleteCTRRollupsChunk})
+		default:
+			return 0, fmt.Errorf("cannot delete CTR counts per %s", bucket)
+		}
+		deleted += n
+		if err != nil || n < deleteCTRRollupsChunk {
+			return deleted, err
+		}
+	}
+}
@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ func (suite *LogRepositorySuite) TearDownTest() {
 // TestInsertLog tests the insertion of a log entry into the database.
 func (suite *LogRepositorySuite) TestInsertLog() {
 	err := suite.repo.Save(context.Background(), &domain.Log{
+		ID:                 suite.newID(),
 		LogLevel:           "INFO",
 		Date:               time.Now(),
 		DestinationService: "UserService",
@@ -47,9 +49,31 @@ func (suite *LogRepositorySuite) TestInsertLog() {
 	require.NoError(suite.T(), err, "Failed to insert log.")
 }
 
+// TestInsertLogBatch tests the insertion of several log entries at once.
+func (suite *LogRepositorySuite) TestInsertLogBatch() {
+	var logs []*domain.Log
+	for i := 0; i < 3; i++ {
+		logs = append(logs, &domain.Log{
+			ID:                 suite.newID(),
+			LogLevel:           "INFO",
+			Date:               time.Now(),
+			DestinationService: "UserService",
+			SourceService:      "TestInsertLogBatch",
+			RequestType:        "POST",
+			Content:            "Batched log.",
+		})
+	}
+	require.NoError(suite.T(), suite.repo.SaveBatch(context.Background(), logs), "Failed to insert logs.")
+
+	results, err := suite.repo.List(context.Background(), &domain.LogFilter{SourceService: "TestInsertLogBatch", Limit: 10})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 3)
+}
+
 // TestList tests the retrieval of log entries from the database.
 func (suite *LogRepositorySuite) TestList() {
 	err := suite.repo.Save(context.Background(), &domain.Log{
+		ID:                 suite.newID(),
 		LogLevel:           "INFO",
 		Date:               time.Now(),
 		DestinationService: "UserService",
@@ -59,14 +83,288 @@ func (suite *LogRepositorySuite) TestList() {
 	})
 	require.NoError(suite.T(), err)
 
-	results, err := suite.repo.List(context.Background())
+	results, err := suite.repo.List(context.Background(), &domain.LogFilter{Limit: 100})
 	require.NoError(suite.T(), err, "Failed to get logs.")
 	assert.GreaterOrEqual(suite.T(), len(results), 1, "Want 1 logs but got %d", len(results))
 }
 
+// TestListFilter tests filtering, sorting and keyset pagination of log entries.
+func (suite *LogRepositorySuite) TestListFilter() {
+	base := time.Now().UTC().Truncate(time.Second)
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id := suite.newID()
+		ids = append(ids, id)
+		err := suite.repo.Save(context.Background(), &domain.Log{
+			ID:                 id,
+			LogLevel:           "WARN",
+			Date:               base.Add(time.Duration(i) * time.Minute),
+			DestinationService: "FilterService",
+			SourceService:      "TestListFilter",
+			RequestType:        "GET",
+			Content:            "50% done_" + id,
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	filter := &domain.LogFilter{
+		SourceService: "TestListFilter",
+		Content:       "50% done_",
+		Order:         domain.SortOrderAsc,
+		Limit:         2,
+	}
+	page, err := suite.repo.List(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 2)
+	assert.Equal(suite.T(), ids[0], page[0].ID)
+	assert.Equal(suite.T(), ids[1], page[1].ID)
+
+	filter.After = &domain.LogCursor{Date: page[1].Date, ID: page[1].ID}
+	page, err = suite.repo.List(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 1)
+	assert.Equal(suite.T(), ids[2], page[0].ID)
+
+	filter = &domain.LogFilter{
+		SourceService: "TestListFilter",
+		From:          base.Add(time.Minute),
+		Order:         domain.SortOrderDesc,
+		Limit:         10,
+	}
+	page, err = suite.repo.List(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 2)
+	assert.Equal(suite.T(), ids[2], page[0].ID)
+	assert.Equal(suite.T(), ids[1], page[1].ID)
+}
+
+// TestListAgreesWithMatches tests that List returns the logs matched by
+// LogFilter.Matches, which filters the streamed logs.
+func (suite *LogRepositorySuite) TestListAgreesWithMatches() {
+	source := suite.newID()
+	contents := []string{"50% done", "500 done", "Payment DECLINED", "user_id=42", "userXid=42"}
+	for _, content := range contents {
+		err := suite.repo.Save(context.Background(), &domain.Log{
+			ID:                 suite.newID(),
+			LogLevel:           "INFO",
+			Date:               time.Now(),
+			DestinationService: "MatchService",
+			SourceService:      source,
+			RequestType:        "GET",
+			Content:            content,
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	for _, filter := range []*domain.LogFilter{
+		{SourceService: source, Content: "50%"},
+		{SourceService: source, Content: "payment declined"},
+		{SourceService: source, Content: "user_id"},
+		{SourceService: strings.ToUpper(source), DestinationService: "matchservice", RequestType: "get"},
+	} {
+		filter.Limit = 10
+		results, err := suite.repo.List(context.Background(), filter)
+		require.NoError(suite.T(), err)
+		require.NotEmpty(suite.T(), results, "List(%+v) returned no logs.", filter)
+
+		var matched int
+		for i := range results {
+			assert.True(suite.T(), filter.Matches(&results[i]), "Matches(%q) = false for %+v.", results[i].Content, filter)
+		}
+		for _, content := range contents {
+			log := &domain.Log{LogLevel: "INFO", DestinationService: "MatchService", SourceService: source, RequestType: "GET", Content: content}
+			if filter.Matches(log) {
+				matched++
+			}
+		}
+		assert.Equal(suite.T(), matched, len(results), "List and Matches disagree on %+v.", filter)
+	}
+}
+
+// TestListSeverity tests filtering log entries by exact and minimum severity.
+func (suite *LogRepositorySuite) TestListSeverity() {
+	base := time.Now().UTC().Truncate(time.Second)
+	// "warning" was stored before levels were normalized on ingest.
+	levels := []string{"DEBUG", "warning", "ERROR"}
+	ids := make([]string, len(levels))
+	for i, level := range levels {
+		ids[i] = suite.newID()
+		err := suite.repo.Save(context.Background(), &domain.Log{
+			ID:            ids[i],
+			LogLevel:      level,
+			Date:          base.Add(time.Duration(i) * time.Minute),
+			SourceService: "TestListSeverity",
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	page, err := suite.repo.List(context.Background(), &domain.LogFilter{
+		SourceService: "TestListSeverity",
+		MinSeverity:   domain.SeverityWarn,
+		Order:         domain.SortOrderAsc,
+		Limit:         10,
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 2)
+	assert.Equal(suite.T(), ids[1], page[0].ID)
+	assert.Equal(suite.T(), ids[2], page[1].ID)
+
+	page, err = suite.repo.List(context.Background(), &domain.LogFilter{
+		SourceService: "TestListSeverity",
+		Severity:      domain.SeverityWarn,
+		Order:         domain.SortOrderAsc,
+		Limit:         10,
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 1)
+	assert.Equal(suite.T(), ids[1], page[0].ID)
+}
+
+// TestListAttributes tests filtering log entries by attribute values.
+func (suite *LogRepositorySuite) TestListAttributes() {
+	var ids []string
+	for _, attributes := range []map[string]any{
+		{"status": float64(500), "user_id": "u-1"},
+		{"status": float64(200), "user_id": "u-1"},
+		nil,
+	} {
+		id := suite.newID()
+		ids = append(ids, id)
+		require.NoError(suite.T(), suite.repo.SaveBatch(context.Background(), []*domain.Log{{
+			ID:            id,
+			LogLevel:      "INFO",
+			Date:          time.Now(),
+			SourceService: "TestListAttributes",
+			Attributes:    attributes,
+		}}))
+	}
+
+	filter := &domain.LogFilter{
+		SourceService: "TestListAttributes",
+		Attributes:    map[string]any{"user_id": "u-1"},
+		Order:         domain.SortOrderAsc,
+		Limit:         10,
+	}
+	page, err := suite.repo.List(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 2)
+	assert.Equal(suite.T(), ids[0], page[0].ID)
+	assert.Equal(suite.T(), float64(500), page[0].Attributes["status"])
+
+	filter.Attributes = map[string]any{"user_id": "u-1", "status": float64(200)}
+	page, err = suite.repo.List(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 1)
+	assert.Equal(suite.T(), ids[1], page[0].ID)
+}
+
+// TestGet tests the retrieval of a single log entry by its ID.
+func (suite *LogRepositorySuite) TestGet() {
+	want := &domain.Log{
+		ID:                 suite.newID(),
+		LogLevel:           "INFO",
+		Date:               time.Now().UTC().Truncate(time.Second),
+		DestinationService: "UserService",
+		SourceService:      "AuthService",
+		RequestType:        "GET",
+		Content:            "Test Get Log By ID.",
+		Attributes:         map[string]any{"user_id": "u-1", "status": float64(200)},
+	}
+	require.NoError(suite.T(), suite.repo.Save(context.Background(), want))
+
+	got, err := suite.repo.Get(context.Background(), want.ID)
+	require.NoError(suite.T(), err, "Failed to get log.")
+	assert.Equal(suite.T(), want.ID, got.ID)
+	assert.Equal(suite.T(), want.Content, got.Content)
+	assert.Equal(suite.T(), want.Attributes, got.Attributes)
+
+	_, err = suite.repo.Get(context.Background(), suite.newID())
+	assert.ErrorIs(suite.T(), err, domain.ErrLogNotFound)
+}
+
+// TestSaveBatchUTF8MB4 tests that 4-byte characters survive a round trip
+// through the utf8mb4 tables.
+func (suite *LogRepositorySuite) TestSaveBatchUTF8MB4() {
+	want := &domain.Log{
+		ID:                 suite.newID(),
+		LogLevel:           "INFO",
+		Date:               time.Now().UTC().Truncate(time.Second),
+		DestinationService: "UserService",
+		SourceService:      "Auth🔐Service",
+		RequestType:        "GET",
+		Content:            "Logged in 🎉",
+	}
+	require.NoError(suite.T(), suite.repo.SaveBatch(context.Background(), []*domain.Log{want}))
+
+	got, err := suite.repo.Get(context.Background(), want.ID)
+	require.NoError(suite.T(), err, "Failed to get log.")
+	assert.Equal(suite.T(), want.SourceService, got.SourceService)
+	assert.Equal(suite.T(), want.Content, got.Content)
+}
+
+// TestListTrace tests the retrieval of the log entries of a trace in time order.
+func (suite *LogRepositorySuite) TestListTrace() {
+	traceID := strings.ReplaceAll(suite.newID(), "-", "")
+	base := time.Now().UTC().Truncate(time.Second)
+	logs := []*domain.Log{
+		{ID: suite.newID(), LogLevel: "INFO", Date: base.Add(time.Second), TraceID: traceID, SpanID: "00f067aa0ba902b7", ParentSpanID: "b7ad6b7169203331"},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base, TraceID: traceID, SpanID: "b7ad6b7169203331"},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base},
+	}
+	require.NoError(suite.T(), suite.repo.SaveBatch(context.Background(), logs))
+
+	got, err := suite.repo.ListTrace(context.Background(), traceID, 10)
+	require.NoError(suite.T(), err, "Failed to list trace logs.")
+	require.Len(suite.T(), got, 2)
+	assert.Equal(suite.T(), logs[1].ID, got[0].ID)
+	assert.Equal(suite.T(), logs[0].ID, got[1].ID)
+	assert.Equal(suite.T(), "b7ad6b7169203331", got[1].ParentSpanID)
+
+	got, err = suite.repo.ListTrace(context.Background(), traceID, 1)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), got, 1)
+}
+
+// TestServiceCallCounts tests the counting of logs between services per log level.
+func (suite *LogRepositorySuite) TestServiceCallCounts() {
+	// The services are unique to this test and the logs are dated in the
+	// past, so that logs stored by other tests are not counted.
+	prefix := suite.newID()
+	auth, user := prefix+"-auth", prefix+"-user"
+	base := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	logs := []*domain.Log{
+		{ID: suite.newID(), LogLevel: "INFO", Date: base, SourceService: auth, DestinationService: user},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base.Add(time.Minute), SourceService: auth, DestinationService: user},
+		{ID: suite.newID(), LogLevel: "ERROR", Date: base.Add(time.Minute), SourceService: auth, DestinationService: user},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base, SourceService: user, DestinationService: auth},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base, SourceService: user},
+		{ID: suite.newID(), LogLevel: "INFO", Date: base.Add(time.Hour), SourceService: auth, DestinationService: user},
+	}
+	require.NoError(suite.T(), suite.repo.SaveBatch(context.Background(), logs))
+
+	got, err := suite.repo.ServiceCallCounts(context.Background(), &domain.ServiceGraphFilter{
+		From: base,
+		To:   base.Add(time.Hour),
+	})
+	require.NoError(suite.T(), err, "Failed to count service calls.")
+
+	var counts []domain.ServiceCallCount
+	for _, count := range got {
+		if strings.HasPrefix(count.SourceService, prefix) {
+			counts = append(counts, count)
+		}
+	}
+	assert.Equal(suite.T(), []domain.ServiceCallCount{
+		{SourceService: auth, DestinationService: user, LogLevel: "ERROR", Count: 1},
+		{SourceService: auth, DestinationService: user, LogLevel: "INFO", Count: 2},
+		{SourceService: user, DestinationService: auth, LogLevel: "INFO", Count: 1},
+	}, counts)
+}
+
 // TestInsertCTRLog tests the insertion of a CTR log entry into the database.
 func (suite *LogRepositorySuite) TestInsertCTRLog() {
 	err := suite.repo.CTRSave(context.Background(), &domain.CTRLog{
+		ID:        suite.newID(),
 		EventType: "tap",
 		CreatedAt: time.Now(),
 		ObjectID:  "123456",
@@ -74,9 +372,19 @@ func (suite *LogRepositorySuite) TestInsertCTRLog() {
 	assert.NoError(suite.T(), err, "Failed to insert CTR log.")
 }
 
+// TestInsertCTRLogBatch tests the insertion of several CTR log entries at once.
+func (suite *LogRepositorySuite) TestInsertCTRLogBatch() {
+	ctrLogs := []*domain.CTRLog{
+		{ID: suite.newID(), EventType: "impression", CreatedAt: time.Now(), ObjectID: "123456"},
+		{ID: suite.newID(), EventType: "click", CreatedAt: time.Now(), ObjectID: "123456"},
+	}
+	assert.NoError(suite.T(), suite.repo.CTRSaveBatch(context.Background(), ctrLogs), "Failed to insert CTR logs.")
+}
+
 // TestListCTRLogs tests the retrieval of CTR log entries from the database.
 func (suite *LogRepositorySuite) TestListCTRLogs() {
 	err := suite.repo.CTRSave(context.Background(), &domain.CTRLog{
+		ID:        suite.newID(),
 		EventType: "tap",
 		CreatedAt: time.Now(),
 		ObjectID:  "123456",
@@ -88,6 +396,139 @@ func (suite *LogRepositorySuite) TestListCTRLogs() {
 	assert.GreaterOrEqual(suite.T(), len(results), 1, "Want 1 CTR logs but got %d", len(results))
 }
 
+// TestCTRStats tests the counting of CTR events per object and time bucket
+// in the rollup tables.
+func (suite *LogRepositorySuite) TestCTRStats() {
+	objectID := suite.newID()
+	base := time.Date(2001, 2, 3, 10, 0, 0, 0, time.UTC)
+	ctrLogs := []*domain.CTRLog{
+		{ID: suite.newID(), EventType: "impression", CreatedAt: base.Add(5 * time.Minute), ObjectID: objectID},
+		{ID: suite.newID(), EventType: "impression", CreatedAt: base.Add(10 * time.Minute), ObjectID: objectID},
+		{ID: suite.newID(), EventType: "click", CreatedAt: base.Add(15 * time.Minute), ObjectID: objectID},
+		{ID: suite.newID(), EventType: "impression", CreatedAt: base.Add(time.Hour), ObjectID: objectID},
+	}
+	require.NoError(suite.T(), suite.repo.CTRSaveBatch(context.Background(), ctrLogs))
+
+	filter := &domain.CTRStatsFilter{From: base, To: base.Add(24 * time.Hour), Bucket: domain.CTRBucketHour, ObjectIDs: []string{objectID}}
+	hourly, err := suite.repo.CTRStats(context.Background(), filter)
+	require.NoError(suite.T(), err, "Failed to get hourly CTR stats.")
+	assert.Equal(suite.T(), []domain.CTRStat{
+		{ObjectID: objectID, BucketStart: base, Impressions: 2, Clicks: 1},
+		{ObjectID: objectID, BucketStart: base.Add(time.Hour), Impressions: 1, Clicks: 0},
+	}, statsOf(hourly, objectID))
+
+	filter.Bucket = domain.CTRBucketDay
+	daily, err := suite.repo.CTRStats(context.Background(), filter)
+	require.NoError(suite.T(), err, "Failed to get daily CTR stats.")
+	assert.Equal(suite.T(), []domain.CTRStat{
+		{ObjectID: objectID, BucketStart: time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC), Impressions: 3, Clicks: 1},
+	}, statsOf(daily, objectID))
+
+	// A single event is added to the existing counts.
+	require.NoError(suite.T(), suite.repo.CTRSave(context.Background(), &domain.CTRLog{
+		ID: suite.newID(), EventType: "click", CreatedAt: base.Add(6 * time.Minute), ObjectID: objectID,
+	}))
+	filter.Bucket = domain.CTRBucketMinute
+	minutely, err := suite.repo.CTRStats(context.Background(), filter)
+	require.NoError(suite.T(), err, "Failed to get per-minute CTR stats.")
+	assert.Equal(suite.T(), []domain.CTRStat{
+		{ObjectID: objectID, BucketStart: base.Add(5 * time.Minute), Impressions: 1, Clicks: 0},
+		{ObjectID: objectID, BucketStart: base.Add(6 * time.Minute), Impressions: 0, Clicks: 1},
+		{ObjectID: objectID, BucketStart: base.Add(10 * time.Minute), Impressions: 1, Clicks: 0},
+		{ObjectID: objectID, BucketStart: base.Add(15 * time.Minute), Impressions: 0, Clicks: 1},
+		{ObjectID: objectID, BucketStart: base.Add(time.Hour), Impressions: 1, Clicks: 0},
+	}, statsOf(minutely, objectID))
+}
+
+// TestCTRTopObjects tests the ranking of the objects by their total counts.
+func (suite *LogRepositorySuite) TestCTRTopObjects() {
+	// The time range is used by this test only, so that no other object is ranked.
+	base := time.Date(2001, 4, 5, 10, 0, 0, 0, time.UTC)
+	popular, clicked, unseen := suite.newID(), suite.newID(), suite.newID()
+	var ctrLogs []*domain.CTRLog
+	for i := range 3 {
+		ctrLogs = append(ctrLogs, &domain.CTRLog{ID: suite.newID(), EventType: "impression", CreatedAt: base.Add(time.Duration(i) * time.Hour), ObjectID: popular})
+	}
+	for i := range 3 {
+		ctrLogs = append(ctrLogs, &domain.CTRLog{ID: suite.newID(), EventType: "click", CreatedAt: base.Add(time.Duration(i) * time.Hour), ObjectID: clicked})
+	}
+	ctrLogs = append(ctrLogs,
+		&domain.CTRLog{ID: suite.newID(), EventType: "click", CreatedAt: base, ObjectID: popular},
+		&domain.CTRLog{ID: suite.newID(), EventType: "impression", CreatedAt: base, ObjectID: clicked},
+		&domain.CTRLog{ID: suite.newID(), EventType: "click", CreatedAt: base, ObjectID: unseen},
+		&domain.CTRLog{ID: suite.newID(), EventType: "click", CreatedAt: base, ObjectID: unseen},
+	)
+	require.NoError(suite.T(), suite.repo.CTRSaveBatch(context.Background(), ctrLogs))
+
+	filter := &domain.CTRStatsFilter{From: base, To: base.Add(24 * time.Hour), Bucket: domain.CTRBucketHour}
+	testCases := map[domain.CTRStatsSort][]domain.CTRTotal{
+		domain.CTRStatsSortImpressions: {
+			{ObjectID: popular, Impressions: 3, Clicks: 1},
+			{ObjectID: clicked, Impressions: 1, Clicks: 3},
+		},
+		domain.CTRStatsSortClicks: {
+			{ObjectID: clicked, Impressions: 1, Clicks: 3},
+			{ObjectID: unseen, Impressions: 0, Clicks: 2},
+		},
+		// Objects without impressions have a click-through rate of 0.
+		domain.CTRStatsSortCTR: {
+			{ObjectID: clicked, Impressions: 1, Clicks: 3},
+			{ObjectID: popular, Impressions: 3, Clicks: 1},
+		},
+	}
+	for sortBy, want := range testCases {
+		totals, err := suite.repo.CTRTopObjects(context.Background(), filter, sortBy, 2)
+		require.NoError(suite.T(), err, "Failed to rank the objects by %s.", sortBy)
+		assert.Equal(suite.T(), want, totals, "Objects ranked by %s.", sortBy)
+	}
+}
+
+// TestDeleteCTRStats tests the deletion of expired per-minute counts.
+func (suite *LogRepositorySuite) TestDeleteCTRStats() {
+	objectID := suite.newID()
+	base := time.Date(2001, 3, 4, 10, 0, 0, 0, time.UTC)
+	ctrLogs := []*domain.CTRLog{
+		{ID: suite.newID(), EventType: "impression", CreatedAt: base, ObjectID: objectID},
+		{ID: suite.newID(), EventType: "impression", CreatedAt: base.Add(time.Hour), ObjectID: objectID},
+	}
+	require.NoError(suite.T(), suite.repo.CTRSaveBatch(context.Background(), ctrLogs))
+
+	deleted, err := suite.repo.DeleteCTRStats(context.Background(), domain.CTRBucketMinute, base.Add(time.Minute))
+	require.NoError(suite.T(), err, "Failed to delete CTR stats.")
+	assert.GreaterOrEqual(suite.T(), deleted, int64(1))
+
+	filter := &domain.CTRStatsFilter{From: base, To: base.Add(24 * time.Hour), Bucket: domain.CTRBucketMinute, ObjectIDs: []string{objectID}}
+	minutely, err := suite.repo.CTRStats(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []domain.CTRStat{
+		{ObjectID: objectID, BucketStart: base.Add(time.Hour), Impressions: 1, Clicks: 0},
+	}, statsOf(minutely, objectID))
+
+	// The per-hour counts are kept.
+	filter.Bucket = domain.CTRBucketHour
+	hourly, err := suite.repo.CTRStats(context.Background(), filter)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), statsOf(hourly, objectID), 2)
+}
+
+// statsOf returns the stats of the given object, ignoring rows left by other tests.
+func statsOf(stats []domain.CTRStat, objectID string) []domain.CTRStat {
+	var result []domain.CTRStat
+	for _, stat := range stats {
+		if stat.ObjectID == objectID {
+			result = append(result, stat)
+		}
+	}
+	return result
+}
+
+// newID returns a fresh log ID, failing the test if one cannot be generated.
+func (suite *LogRepositorySuite) newID() string {
+	id, err := domain.NewLogID()
+	require.NoError(suite.T(), err)
+	return id
+}
+
 // TestLogRepositorySuite runs the LogRepositorySuite tests using testify's suite package.
 func TestLogRepositorySuite(t *testing.T) {
 	suite.Run(t, new(LogRepositorySuite))
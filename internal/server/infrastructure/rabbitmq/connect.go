@@ -1,52 +1,143 @@
 package rabbitmq
 
 import (
-	"log"
-	"os"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const (
-	QUEUE_NAME = "logs"
+	// LOG_QUEUE_NAME is the queue that receives general service logs
+	// published by the client and pkg/logger.
+	LOG_QUEUE_NAME = "service_logs"
+	// LOG_DEAD_LETTER_QUEUE_NAME holds the service logs that could not be
+	// stored. Service logs are not retried.
+	LOG_DEAD_LETTER_QUEUE_NAME = "service_logs.dlq"
+	// CTR_LOG_QUEUE_NAME is the queue that receives CTR events.
+	CTR_LOG_QUEUE_NAME = "ctr_logs"
+	// CTR_LOG_RETRY_QUEUE_PREFIX prefixes the names of the queues holding
+	// the CTR events waiting to be retried, one per backoff delay, such as
+	// ctr_logs.retry.2s. They have no consumer: each event expires after the
+	// delay of its queue and is dead-lettered back to CTR_LOG_QUEUE_NAME.
+	CTR_LOG_RETRY_QUEUE_PREFIX = "ctr_logs.retry."
+	// CTR_LOG_DEAD_LETTER_QUEUE_NAME holds the CTR events that were given up on.
+	CTR_LOG_DEAD_LETTER_QUEUE_NAME = "ctr_logs.dlq"
+	// DEAD_LETTER_EXCHANGE_NAME routes the messages given up on to the
+	// dead-letter queue of their original queue.
+	DEAD_LETTER_EXCHANGE_NAME = "log_service.dlx"
+
+	// LOG_CONSUMER_TAG and CTR_LOG_CONSUMER_TAG identify the server's
+	// consumers so that they can be cancelled on shutdown.
+	LOG_CONSUMER_TAG     = "log_service.service_logs"
+	CTR_LOG_CONSUMER_TAG = "log_service.ctr_logs"
 )
 
-func Connect() (*amqp.Connection, *amqp.Channel, <-chan amqp.Delivery, error) {
-	conn, err := amqp.Dial(os.Getenv("RABBITMQ_URL"))
-	if err != nil {
-		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
-		return nil, nil, nil, err
+// declareTopology declares the queues consumed by the server along with
+// their dead-letter queues. The retry queues are declared as they are used,
+// since their delays depend on the retry policy.
+func declareTopology(ch *amqp.Channel) error {
+	for _, queueName := range []string{LOG_QUEUE_NAME, CTR_LOG_QUEUE_NAME} {
+		if _, err := declareQueue(ch, queueName); err != nil {
+			return err
+		}
 	}
+	if err := declareDeadLettering(ch, LOG_QUEUE_NAME, LOG_DEAD_LETTER_QUEUE_NAME); err != nil {
+		return err
+	}
+	return declareDeadLettering(ch, CTR_LOG_QUEUE_NAME, CTR_LOG_DEAD_LETTER_QUEUE_NAME)
+}
+
+// declareQueue declares the durable queue queueName.
+func declareQueue(ch *amqp.Channel, queueName string) (amqp.Queue, error) {
+	return ch.QueueDeclare(
+		queueName, // name
+		true,      // durable
+		false,     // delete when unused
+		false,     // exclusive
+		false,     // no-wait
+		nil,       // arguments
+	)
+}
 
-	ch, err := conn.Channel()
+// consume starts consuming the queue queueName with the given consumer tag.
+func consume(ch *amqp.Channel, queueName, consumerTag string) (<-chan amqp.Delivery, error) {
+	msgs, err := ch.Consume(
+		queueName,   // queue
+		consumerTag, // consumer
+		false,       // auto-ack
+		false,       // exclusive
+		false,       // no-local
+		false,       // no-wait
+		nil,         // args
+	)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
+	return msgs, nil
+}
 
-	q, err := ch.QueueDeclare(
-		QUEUE_NAME, // name
-		true,       // durable
-		false,      // delete when unused
-		false,      // exclusive
-		false,      // no-wait
-		nil,        // arguments
+// declareDeadLettering declares the dead-letter queue of queueName. Messages
+// published to DEAD_LETTER_EXCHANGE_NAME with queueName as routing key end up
+// in deadLetterQueue.
+func declareDeadLettering(ch *amqp.Channel, queueName, deadLetterQueue string) error {
+	err := ch.ExchangeDeclare(
+		DEAD_LETTER_EXCHANGE_NAME, // name
+		amqp.ExchangeDirect,       // type
+		true,                      // durable
+		false,                     // auto-deleted
+		false,                     // internal
+		false,                     // no-wait
+		nil,                       // arguments
 	)
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
 
-	msgs, err := ch.Consume(
-		q.Name, // queue
-		"",     // consumer
-		false,  // auto-ack
-		false,  // exclusive
-		false,  // no-local
-		false,  // no-wait
-		nil,    // args
+	_, err = ch.QueueDeclare(
+		deadLetterQueue, // name
+		true,            // durable
+		false,           // delete when unused
+		false,           // exclusive
+		false,           // no-wait
+		nil,             // arguments
 	)
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
 
-	return conn, ch, msgs, nil
+	return ch.QueueBind(
+		deadLetterQueue,           // queue name
+		queueName,                 // routing key
+		DEAD_LETTER_EXCHANGE_NAME, // exchange
+		false,                     // no-wait
+		nil,                       // arguments
+	)
+}
+
+// retryQueueName returns the name of the queue holding the messages retried
+// after delay, given the prefix of the retry queues.
+func retryQueueName(prefix string, delay time.Duration) string {
+	return prefix + delay.String()
+}
+
+// declareRetryQueue declares retryQueue, whose messages are dead-lettered
+// back to queueName once they stayed in it for ttl.
+//
+// The TTL is set on the queue rather than on every message: RabbitMQ only
+// expires messages at the head of a queue, so messages of different delays
+// sharing a queue would wait for the longest one published before them.
+func declareRetryQueue(ch *amqp.Channel, retryQueue, queueName string, ttl time.Duration) error {
+	_, err := ch.QueueDeclare(
+		retryQueue, // name
+		true,       // durable
+		false,      // delete when unused
+		false,      // exclusive
+		false,      // no-wait
+		amqp.Table{
+			"x-message-ttl":             max(ttl.Milliseconds(), 0),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		},
+	)
+	return err
 }
@@ -0,0 +1,235 @@
+package rabbitmq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"log_service/internal/server/domain"
+)
+
+// DEAD_LETTERED_AT_HEADER holds the time at which a message was dead-lettered.
+const DEAD_LETTERED_AT_HEADER = "x-dead-lettered-at"
+
+// deadLetterQueues are the prefix of the retry queues and the dead-letter
+// queue of a consumed queue. retryPrefix is empty for a queue without retries.
+type deadLetterQueues struct {
+	retryPrefix string
+	deadLetter  string
+}
+
+// DeadLetterRepository implements domain.IDeadLetterRepository on the
+// dead-letter queues declared by the Supervisor.
+//
+// RabbitMQ cannot address a message in the middle of a queue, so every
+// operation gets the messages from the head of the queue on its own channel
+// and requeues the ones it leaves untouched.
+type DeadLetterRepository struct {
+	supervisor *Supervisor
+	queues     map[string]deadLetterQueues
+}
+
+func NewDeadLetterRepository(supervisor *Supervisor) *DeadLetterRepository {
+	return &DeadLetterRepository{
+		supervisor: supervisor,
+		queues: map[string]deadLetterQueues{
+			LOG_QUEUE_NAME:     {deadLetter: LOG_DEAD_LETTER_QUEUE_NAME},
+			CTR_LOG_QUEUE_NAME: {retryPrefix: CTR_LOG_RETRY_QUEUE_PREFIX, deadLetter: CTR_LOG_DEAD_LETTER_QUEUE_NAME},
+		},
+	}
+}
+
+// scanAction tells scan what to do with a dead-lettered message.
+type scanAction int
+
+const (
+	// scanKeep leaves the message in the dead-letter queue.
+	scanKeep scanAction = iota
+	// scanDelete removes the message from the dead-letter queue.
+	scanDelete
+	// scanReplay publishes the message back to its original queue and removes
+	// it from the dead-letter queue.
+	scanReplay
+	// scanStop leaves the message in the dead-letter queue and ends the scan.
+	scanStop
+)
+
+func (r *DeadLetterRepository) ListDeadLetters(ctx context.Context, queue string, limit int) ([]*domain.DeadLetter, int, error) {
+	var deadLetters []*domain.DeadLetter
+	count, err := r.scan(ctx, queue, func(dl *domain.DeadLetter) scanAction {
+		if len(deadLetters) >= limit {
+			return scanStop
+		}
+		deadLetters = append(deadLetters, dl)
+		return scanKeep
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return deadLetters, count, nil
+}
+
+func (r *DeadLetterRepository) GetDeadLetter(ctx context.Context, queue, id string) (*domain.DeadLetter, error) {
+	var found *domain.DeadLetter
+	_, err := r.scan(ctx, queue, func(dl *domain.DeadLetter) scanAction {
+		if dl.ID != id {
+			return scanKeep
+		}
+		found = dl
+		return scanStop
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+	return found, nil
+}
+
+func (r *DeadLetterRepository) ReplayDeadLetters(ctx context.Context, queue string, ids []string) (int, error) {
+	return r.settle(ctx, queue, ids, scanReplay)
+}
+
+func (r *DeadLetterRepository) PurgeDeadLetters(ctx context.Context, queue string, ids []string) (int, error) {
+	if len(ids) == 0 {
+		q, ok := r.queues[queue]
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+		}
+		ch, err := r.supervisor.Channel()
+		if err != nil {
+			return 0, err
+		}
+		defer ch.Close()
+		return ch.QueuePurge(q.deadLetter, false)
+	}
+	return r.settle(ctx, queue, ids, scanDelete)
+}
+
+// settle applies action to the messages with the given IDs, or to all of
+// them if ids is empty, and returns to how many it was applied.
+func (r *DeadLetterRepository) settle(ctx context.Context, queue string, ids []string, action scanAction) (int, error) {
+	settled := 0
+	_, err := r.scan(ctx, queue, func(dl *domain.DeadLetter) scanAction {
+		if len(ids) > 0 && !slices.Contains(ids, dl.ID) {
+			return scanKeep
+		}
+		settled++
+		return action
+	})
+	return settled, err
+}
+
+// scan passes the messages in the dead-letter queue of queue to visit, oldest
+// first, and applies the returned actions. It returns the number of messages
+// in the dead-letter queue when the scan started.
+//
+// Only the messages present when the scan starts are visited, so that a
+// replayed message that fails again is not visited twice.
+func (r *DeadLetterRepository) scan(ctx context.Context, queue string, visit func(*domain.DeadLetter) scanAction) (int, error) {
+	q, ok := r.queues[queue]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+	}
+
+	ch, err := r.supervisor.Channel()
+	if err != nil {
+		return 0, err
+	}
+	// Closing the channel requeues the messages that were left unsettled.
+	defer ch.Close()
+	if err := ch.Confirm(false); err != nil {
+		return 0, err
+	}
+
+	dlq, err := ch.QueueDeclarePassive(q.deadLetter, true, false, false, false, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastKept uint64
+	for i := 0; i < dlq.Messages; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		d, ok, err := ch.Get(q.deadLetter, false)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+
+		action := visit(newDeadLetter(queue, q.retryPrefix, d))
+		if action == scanStop {
+			lastKept = d.DeliveryTag
+			break
+		}
+		switch action {
+		case scanKeep:
+			lastKept = d.DeliveryTag
+			continue
+		case scanReplay:
+			if err := replay(ctx, ch, queue, d); err != nil {
+				return 0, err
+			}
+		}
+		if err := d.Ack(false); err != nil {
+			return 0, err
+		}
+	}
+
+	if lastKept > 0 {
+		if err := ch.Nack(lastKept, true, true); err != nil {
+			return 0, err
+		}
+	}
+	return dlq.Messages, nil
+}
+
+// replay publishes d to queue as a fresh message, so that it gets the retries
+// of its queue again.
+func replay(ctx context.Context, ch *amqp.Channel, queue string, d amqp.Delivery) error {
+	headers := copyHeaders(d.Headers)
+	for _, key := range []string{"x-death", "x-first-death-exchange", "x-first-death-queue", "x-first-death-reason",
+		"x-last-death-exchange", "x-last-death-queue", "x-last-death-reason",
+		RETRY_COUNT_HEADER, FAILURE_REASON_HEADER, DEAD_LETTERED_AT_HEADER} {
+		delete(headers, key)
+	}
+
+	return publishWithConfirm(ctx, ch, "", queue, republishing(d, headers))
+}
+
+// newDeadLetter converts a message of the dead-letter queue of queue.
+func newDeadLetter(queue, retryQueuePrefix string, d amqp.Delivery) *domain.DeadLetter {
+	dl := &domain.DeadLetter{
+		ID:          deadLetterID(d),
+		Queue:       queue,
+		Retries:     retryCount(d.Headers, retryQueuePrefix),
+		ContentType: d.ContentType,
+		Body:        d.Body,
+	}
+	if reason, ok := d.Headers[FAILURE_REASON_HEADER].(string); ok {
+		dl.Reason = reason
+	}
+	if at, ok := d.Headers[DEAD_LETTERED_AT_HEADER].(time.Time); ok {
+		dl.DeadLetteredAt = at
+	}
+	return dl
+}
+
+// deadLetterID identifies a dead-lettered message by its message ID, or by
+// the digest of its body when it has none.
+func deadLetterID(d amqp.Delivery) string {
+	if d.MessageId != "" {
+		return d.MessageId
+	}
+	sum := sha256.Sum256(d.Body)
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,51 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"log_service/internal/server/domain"
+)
+
+func TestNewDeadLetter(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+
+	got := newDeadLetter(CTR_LOG_QUEUE_NAME, CTR_LOG_RETRY_QUEUE_PREFIX, amqp.Delivery{
+		MessageId:   "msg-1",
+		ContentType: "application/json",
+		Headers: amqp.Table{
+			FAILURE_REASON_HEADER:   "failed to insert CTR log after 2 retries: db error",
+			DEAD_LETTERED_AT_HEADER: at,
+			RETRY_COUNT_HEADER:      int64(2),
+		},
+		Body: []byte(`{}`),
+	})
+	want := &domain.DeadLetter{
+		ID:             "msg-1",
+		Queue:          CTR_LOG_QUEUE_NAME,
+		Reason:         "failed to insert CTR log after 2 retries: db error",
+		DeadLetteredAt: at,
+		Retries:        2,
+		ContentType:    "application/json",
+		Body:           []byte(`{}`),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newDeadLetter() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeadLetterID(t *testing.T) {
+	t.Parallel()
+	a := deadLetterID(amqp.Delivery{Body: []byte(`{"objectId":"a"}`)})
+	b := deadLetterID(amqp.Delivery{Body: []byte(`{"objectId":"b"}`)})
+	if a == b || len(a) != 32 {
+		t.Errorf("deadLetterID() = %q and %q, want distinct 32-digit IDs", a, b)
+	}
+	if got := deadLetterID(amqp.Delivery{MessageId: "msg-1", Body: []byte(`{}`)}); got != "msg-1" {
+		t.Errorf("deadLetterID() = %q, want the message ID", got)
+	}
+}
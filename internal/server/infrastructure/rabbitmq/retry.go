@@ -0,0 +1,138 @@
+package rabbitmq
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// RETRY_COUNT_HEADER counts the retries of a message. It backs up the
+	// x-death header, which brokers may not let publishers carry over.
+	RETRY_COUNT_HEADER = "x-retry-count"
+	// FAILURE_REASON_HEADER tells why a dead-lettered message was given up on.
+	FAILURE_REASON_HEADER = "x-failure-reason"
+)
+
+// DeadLetterPublisher republishes the deliveries of a queue to its
+// dead-letter queue declared by the Supervisor.
+//
+// It publishes in confirm mode, so that a delivery is only acknowledged once
+// its copy has been accepted by the broker.
+type DeadLetterPublisher struct {
+	supervisor *Supervisor
+	queueName  string
+}
+
+// NewLogDeadLetterPublisher returns the DeadLetterPublisher of LOG_QUEUE_NAME.
+func NewLogDeadLetterPublisher(supervisor *Supervisor) *DeadLetterPublisher {
+	return &DeadLetterPublisher{supervisor: supervisor, queueName: LOG_QUEUE_NAME}
+}
+
+// RetryPublisher also republishes the deliveries of a queue to its retry
+// queues, one per backoff delay.
+type RetryPublisher struct {
+	DeadLetterPublisher
+	retryQueuePrefix string
+}
+
+// NewCTRRetryPublisher returns the RetryPublisher of CTR_LOG_QUEUE_NAME.
+func NewCTRRetryPublisher(supervisor *Supervisor) *RetryPublisher {
+	return &RetryPublisher{
+		DeadLetterPublisher: DeadLetterPublisher{supervisor: supervisor, queueName: CTR_LOG_QUEUE_NAME},
+		retryQueuePrefix:    CTR_LOG_RETRY_QUEUE_PREFIX,
+	}
+}
+
+// Retries returns how many times msg went through the retry queues.
+func (p *RetryPublisher) Retries(msg amqp.Delivery) int {
+	return retryCount(msg.Headers, p.retryQueuePrefix)
+}
+
+// PublishRetry publishes msg to the retry queue of delay, from which it is
+// dead-lettered back to its queue once delay elapsed.
+//
+// The retry queue is declared first, so that it exists whatever the delays
+// of the retry policy and however the broker was reset.
+func (p *RetryPublisher) PublishRetry(ctx context.Context, msg amqp.Delivery, delay time.Duration) error {
+	retryQueue := retryQueueName(p.retryQueuePrefix, delay)
+	if err := p.supervisor.DeclareRetryQueue(retryQueue, p.queueName, delay); err != nil {
+		return err
+	}
+
+	headers := copyHeaders(msg.Headers)
+	headers[RETRY_COUNT_HEADER] = int64(retryCount(msg.Headers, p.retryQueuePrefix) + 1)
+	return p.supervisor.PublishWithConfirm(ctx, "", retryQueue, republishing(msg, headers))
+}
+
+// PublishDeadLetter publishes msg to the dead-letter queue with reason in
+// its FAILURE_REASON_HEADER.
+func (p *DeadLetterPublisher) PublishDeadLetter(ctx context.Context, msg amqp.Delivery, reason string) error {
+	headers := copyHeaders(msg.Headers)
+	headers[FAILURE_REASON_HEADER] = reason
+	headers[DEAD_LETTERED_AT_HEADER] = time.Now().UTC()
+
+	publishing := republishing(msg, headers)
+	// Give the message an ID so that it can be addressed in the dead-letter queue.
+	if publishing.MessageId == "" {
+		publishing.MessageId = uuid.New().String()
+	}
+	return p.supervisor.PublishWithConfirm(ctx, DEAD_LETTER_EXCHANGE_NAME, p.queueName, publishing)
+}
+
+// republishing copies msg into a persistent publishing with the given headers.
+func republishing(msg amqp.Delivery, headers amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		MessageId:       msg.MessageId,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}
+}
+
+func copyHeaders(headers amqp.Table) amqp.Table {
+	copied := make(amqp.Table, len(headers)+2)
+	for k, v := range headers {
+		copied[k] = v
+	}
+	return copied
+}
+
+// retryCount returns how many times a message with the given headers expired
+// in the retry queues named with retryQueuePrefix, according to its x-death
+// header, or to its RETRY_COUNT_HEADER when that is higher. An empty
+// retryQueuePrefix stands for a queue without retries.
+func retryCount(headers amqp.Table, retryQueuePrefix string) int {
+	if retryQueuePrefix == "" {
+		return 0
+	}
+	var count int64
+	if deaths, ok := headers["x-death"].([]any); ok {
+		for _, d := range deaths {
+			death, ok := d.(amqp.Table)
+			if !ok || death["reason"] != "expired" {
+				continue
+			}
+			if queue, ok := death["queue"].(string); !ok || !strings.HasPrefix(queue, retryQueuePrefix) {
+				continue
+			}
+			if n, ok := death["count"].(int64); ok {
+				count += n
+			}
+		}
+	}
+	if n, ok := headers[RETRY_COUNT_HEADER].(int64); ok && n > count {
+		count = n
+	}
+	return int(count)
+}
@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestRetryCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		// noRetries counts for a queue without retry queues.
+		noRetries bool
+		want      int
+	}{
+		{
+			name: "no headers",
+			want: 0,
+		},
+		{
+			name: "x-death of the retry queues",
+			headers: amqp.Table{"x-death": []any{
+				amqp.Table{"queue": "ctr_logs.retry.2s", "reason": "expired", "count": int64(1)},
+				amqp.Table{"queue": "ctr_logs.retry.1s", "reason": "expired", "count": int64(2)},
+				amqp.Table{"queue": CTR_LOG_QUEUE_NAME, "reason": "rejected", "count": int64(5)},
+			}},
+			want: 3,
+		},
+		{
+			name:    "retry count header only",
+			headers: amqp.Table{RETRY_COUNT_HEADER: int64(2)},
+			want:    2,
+		},
+		{
+			name: "highest of both",
+			headers: amqp.Table{
+				RETRY_COUNT_HEADER: int64(2),
+				"x-death": []any{
+					amqp.Table{"queue": "ctr_logs.retry.1s", "reason": "expired", "count": int64(4)},
+				},
+			},
+			want: 4,
+		},
+		{
+			name:    "malformed x-death",
+			headers: amqp.Table{"x-death": "expired"},
+			want:    0,
+		},
+		{
+			name: "queue without retries",
+			headers: amqp.Table{"x-death": []any{
+				amqp.Table{"queue": LOG_QUEUE_NAME, "reason": "expired", "count": int64(1)},
+			}},
+			noRetries: true,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prefix := CTR_LOG_RETRY_QUEUE_PREFIX
+			if tt.noRetries {
+				prefix = ""
+			}
+			if got := retryCount(tt.headers, prefix); got != tt.want {
+				t.Errorf("retryCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryQueueName(t *testing.T) {
+	t.Parallel()
+	for delay, want := range map[time.Duration]string{
+		time.Second:      "ctr_logs.retry.1s",
+		2 * time.Second:  "ctr_logs.retry.2s",
+		90 * time.Second: "ctr_logs.retry.1m30s",
+	} {
+		if got := retryQueueName(CTR_LOG_RETRY_QUEUE_PREFIX, delay); got != want {
+			t.Errorf("retryQueueName(%v) = %q, want %q", delay, got, want)
+		}
+	}
+}
+
+func TestRepublishing(t *testing.T) {
+	t.Parallel()
+	msg := amqp.Delivery{
+		Headers:     amqp.Table{"x-death": []any{}},
+		ContentType: "application/json",
+		MessageId:   "message-id",
+		Body:        []byte(`{}`),
+	}
+	headers := copyHeaders(msg.Headers)
+	headers[FAILURE_REASON_HEADER] = "reason"
+
+	p := republishing(msg, headers)
+	if p.DeliveryMode != amqp.Persistent {
+		t.Errorf("DeliveryMode = %d, want %d", p.DeliveryMode, amqp.Persistent)
+	}
+	if p.MessageId != msg.MessageId || p.ContentType != msg.ContentType || string(p.Body) != string(msg.Body) {
+		t.Errorf("republishing() = %+v, want the properties of %+v", p, msg)
+	}
+	if _, ok := msg.Headers[FAILURE_REASON_HEADER]; ok {
+		t.Errorf("copyHeaders() modified the headers of the delivery")
+	}
+}
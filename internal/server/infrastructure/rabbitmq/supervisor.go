@@ -0,0 +1,410 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	DefaultReconnectMinDelay = time.Second
+	DefaultReconnectMaxDelay = 30 * time.Second
+	DefaultConsumerWorkers   = 4
+	DefaultConsumerPrefetch  = 64
+
+	// publishTimeout bounds the wait for the broker to confirm a publishing.
+	publishTimeout = 5 * time.Second
+)
+
+// ErrNotConnected is returned when the broker is needed while the
+// connection to it is down.
+var ErrNotConnected = errors.New("not connected to RabbitMQ")
+
+// SupervisorConfig controls how the Supervisor reaches the broker.
+type SupervisorConfig struct {
+	URL string
+	// ReconnectMinDelay is the delay before the first reconnection attempt.
+	// It doubles after every failed attempt.
+	ReconnectMinDelay time.Duration
+	// ReconnectMaxDelay caps the delay between two reconnection attempts.
+	ReconnectMaxDelay time.Duration
+	// ConsumerWorkers is the number of workers consuming every queue, each
+	// on its own channel.
+	ConsumerWorkers int
+	// ConsumerPrefetch is the number of unacknowledged deliveries the broker
+	// sends to a worker, which handles all of them concurrently. Handlers wait
+	// for their batch to be committed before acknowledging, so deliveries must
+	// be handled concurrently for batches to fill up.
+	ConsumerPrefetch int
+}
+
+// NewSupervisorConfigFromEnv reads the configuration from RABBITMQ_URL,
+// RABBITMQ_RECONNECT_MIN_DELAY, RABBITMQ_RECONNECT_MAX_DELAY,
+// RABBITMQ_CONSUMER_WORKERS and RABBITMQ_PREFETCH, falling back to the
+// defaults for unset or invalid values.
+func NewSupervisorConfigFromEnv() SupervisorConfig {
+	cfg := SupervisorConfig{
+		URL:               os.Getenv("RABBITMQ_URL"),
+		ReconnectMinDelay: DefaultReconnectMinDelay,
+		ReconnectMaxDelay: DefaultReconnectMaxDelay,
+		ConsumerWorkers:   DefaultConsumerWorkers,
+		ConsumerPrefetch:  DefaultConsumerPrefetch,
+	}
+	if delay, err := time.ParseDuration(os.Getenv("RABBITMQ_RECONNECT_MIN_DELAY")); err == nil && delay > 0 {
+		cfg.ReconnectMinDelay = delay
+	}
+	if delay, err := time.ParseDuration(os.Getenv("RABBITMQ_RECONNECT_MAX_DELAY")); err == nil && delay > 0 {
+		cfg.ReconnectMaxDelay = delay
+	}
+	if workers, err := strconv.Atoi(os.Getenv("RABBITMQ_CONSUMER_WORKERS")); err == nil && workers > 0 {
+		cfg.ConsumerWorkers = workers
+	}
+	if prefetch, err := strconv.Atoi(os.Getenv("RABBITMQ_PREFETCH")); err == nil && prefetch > 0 {
+		cfg.ConsumerPrefetch = prefetch
+	}
+	return cfg
+}
+
+// Status is the state of the connection to the broker.
+type Status struct {
+	Connected bool
+	// Since is when the connection was established or lost.
+	Since time.Time
+	// Reconnects is the number of times the connection was re-established.
+	Reconnects int
+	// LastError is the error that closed the connection or made the last
+	// connection attempt fail.
+	LastError error
+}
+
+// consumer is a queue consumed by the server.
+type consumer struct {
+	queueName   string
+	consumerTag string
+	handle      func(amqp.Delivery)
+}
+
+// Supervisor keeps a connection to the broker open.
+//
+// Whenever the connection or one of its channels is closed, it reconnects
+// with exponential backoff, declares the topology again and resumes the
+// registered consumers. Deliveries received before the connection was lost
+// can no longer be settled and are redelivered by the broker.
+type Supervisor struct {
+	cfg  SupervisorConfig
+	dial func(url string) (*amqp.Connection, error)
+
+	mu        sync.RWMutex
+	consumers []consumer
+	stopped   bool // consumers are not resumed once stopped
+	conn      *amqp.Connection
+	workers   []*worker     // workers of the current connection
+	ch        *amqp.Channel // declares the topology and publishes replies
+	confirmCh *amqp.Channel // publishes in confirm mode
+	status    Status
+
+	closing   chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewSupervisor(cfg SupervisorConfig) *Supervisor {
+	return &Supervisor{
+		cfg:     cfg,
+		dial:    amqp.Dial,
+		closing: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Consume registers handle to be called for every delivery of the queue
+// queueName by the workers of the queue. It must be called before Start.
+func (s *Supervisor) Consume(queueName, consumerTag string, handle func(amqp.Delivery)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumers = append(s.consumers, consumer{queueName: queueName, consumerTag: consumerTag, handle: handle})
+}
+
+// Start connects to the broker in the background and keeps the connection
+// open until Close is called.
+func (s *Supervisor) Start() {
+	go s.supervise()
+}
+
+// supervise connects to the broker and reconnects whenever the connection is lost.
+func (s *Supervisor) supervise() {
+	defer close(s.closed)
+
+	delay := s.cfg.ReconnectMinDelay
+	for {
+		lost, err := s.connect()
+		if err != nil {
+			s.setDisconnected(err)
+			log.Printf("Failed to connect to RabbitMQ, retrying in %v: %v", delay, err)
+			select {
+			case <-time.After(delay):
+			case <-s.closing:
+				return
+			}
+			delay = min(delay*2, s.cfg.ReconnectMaxDelay)
+			continue
+		}
+		delay = s.cfg.ReconnectMinDelay
+
+		select {
+		case err := <-lost:
+			s.setDisconnected(err)
+			log.Printf("Lost connection to RabbitMQ, reconnecting: %v", err)
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// connect opens a connection, declares the topology and starts the
+// consumers. The returned channel receives the error that closed the
+// connection or one of its channels, after which the connection is closed.
+func (s *Supervisor) connect() (<-chan error, error) {
+	conn, err := s.dial(s.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	confirmCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	confirmChClosed := confirmCh.NotifyClose(make(chan *amqp.Error, 1))
+	if err := confirmCh.Confirm(false); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := declareTopology(ch); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stopped {
+		for _, c := range s.consumers {
+			for i := range s.cfg.ConsumerWorkers {
+				w, err := startWorker(conn, c, fmt.Sprintf("%s.%d", c.consumerTag, i), s.cfg.ConsumerPrefetch)
+				if err != nil {
+					conn.Close()
+					return nil, err
+				}
+				s.workers = append(s.workers, w)
+			}
+		}
+	}
+
+	if s.conn != nil {
+		s.status.Reconnects++
+	}
+	s.conn, s.ch, s.confirmCh = conn, ch, confirmCh
+	s.status.Connected = true
+	s.status.Since = time.Now()
+	s.status.LastError = nil
+	log.Printf("Connected to RabbitMQ")
+
+	lost := make(chan error, 1)
+	go func() {
+		var amqpErr *amqp.Error
+		select {
+		case amqpErr = <-connClosed:
+		case amqpErr = <-chClosed:
+		case amqpErr = <-confirmChClosed:
+		}
+		// Close the whole connection when only a channel was closed, so
+		// that everything is set up again.
+		conn.Close()
+		if amqpErr == nil {
+			lost <- amqp.ErrClosed
+			return
+		}
+		lost <- amqpErr
+	}()
+	return lost, nil
+}
+
+func (s *Supervisor) setDisconnected(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status.Connected || s.status.Since.IsZero() {
+		s.status.Since = time.Now()
+	}
+	s.status.Connected = false
+	s.status.LastError = err
+	s.ch, s.confirmCh = nil, nil
+	// The channels of the workers were closed with the connection.
+	s.workers = nil
+}
+
+// Status returns the current state of the connection.
+func (s *Supervisor) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Check returns ErrNotConnected, along with the reason why, if the
+// connection is down. It is meant for health checks.
+func (s *Supervisor) Check(ctx context.Context) error {
+	status := s.Status()
+	if status.Connected {
+		return nil
+	}
+	if status.LastError != nil {
+		return fmt.Errorf("%w: %v", ErrNotConnected, status.LastError)
+	}
+	return ErrNotConnected
+}
+
+// Publish publishes msg on the channel of the current connection.
+func (s *Supervisor) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	s.mu.RLock()
+	ch := s.ch
+	s.mu.RUnlock()
+	if ch == nil {
+		return ErrNotConnected
+	}
+	return ch.Publish(exchange, key, mandatory, immediate, msg)
+}
+
+// PublishWithConfirm publishes msg and waits for the broker to confirm it.
+func (s *Supervisor) PublishWithConfirm(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	s.mu.RLock()
+	ch := s.confirmCh
+	s.mu.RUnlock()
+	if ch == nil {
+		return ErrNotConnected
+	}
+	return publishWithConfirm(ctx, ch, exchange, key, msg)
+}
+
+// DeclareRetryQueue declares retryQueue on the channel of the current
+// connection. Its messages are dead-lettered back to queueName after ttl.
+func (s *Supervisor) DeclareRetryQueue(retryQueue, queueName string, ttl time.Duration) error {
+	s.mu.RLock()
+	ch := s.ch
+	s.mu.RUnlock()
+	if ch == nil {
+		return ErrNotConnected
+	}
+	return declareRetryQueue(ch, retryQueue, queueName, ttl)
+}
+
+// Channel opens a new channel on the current connection. The caller must
+// close it.
+func (s *Supervisor) Channel() (*amqp.Channel, error) {
+	s.mu.RLock()
+	conn, connected := s.conn, s.status.Connected
+	s.mu.RUnlock()
+	if !connected {
+		return nil, ErrNotConnected
+	}
+	return conn.Channel()
+}
+
+// stopConsuming cancels the consumers, which are not resumed on reconnection
+// anymore. Deliveries being handled can still be settled: every worker
+// closes its channel once they are.
+func (s *Supervisor) stopConsuming() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	var errs []error
+	for _, w := range s.workers {
+		errs = append(errs, w.cancel())
+	}
+	return errors.Join(errs...)
+}
+
+// Drain stops consuming and waits until the deliveries being handled are
+// settled. If ctx is done first, the deliveries that are still unsettled are
+// requeued, and an error wrapping the context error tells how many. Their
+// handlers may still complete, so a requeued delivery may be handled twice.
+func (s *Supervisor) Drain(ctx context.Context) error {
+	if err := s.stopConsuming(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	workers := slices.Clone(s.workers)
+	s.mu.RUnlock()
+
+	for i, w := range workers {
+		select {
+		case <-w.done:
+			continue
+		case <-ctx.Done():
+		}
+
+		var requeued int64
+		var errs []error
+		for _, w := range workers[i:] {
+			n, err := w.abort()
+			requeued += n
+			errs = append(errs, err)
+		}
+		if err := errors.Join(errs...); err != nil {
+			return fmt.Errorf("failed to requeue in-flight deliveries: %w", err)
+		}
+		return fmt.Errorf("%w: requeued %d in-flight deliveries", ctx.Err(), requeued)
+	}
+	return nil
+}
+
+// Close stops reconnecting and closes the connection.
+func (s *Supervisor) Close() error {
+	s.closeOnce.Do(func() { close(s.closing) })
+	<-s.closed
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ch, s.confirmCh = nil, nil
+	s.status.Connected = false
+	if s.conn == nil || s.conn.IsClosed() {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// publishWithConfirm publishes msg on ch, which must be in confirm mode, and
+// waits for the broker to confirm it.
+func publishWithConfirm(ctx context.Context, ch *amqp.Channel, exchange, key string, msg amqp.Publishing) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return err
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return fmt.Errorf("publishing to %q was rejected by the broker", key)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package rabbitmq
+
+import (
+	"sync"
+	"sync/atomic"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// workerChannel is the part of *amqp.Channel a worker uses once it consumes.
+type workerChannel interface {
+	Cancel(consumer string, noWait bool) error
+	Nack(tag uint64, multiple, requeue bool) error
+	IsClosed() bool
+	Close() error
+}
+
+// worker consumes a queue on its own channel.
+type worker struct {
+	ch          workerChannel
+	consumerTag string
+	cancelled   atomic.Bool
+	// inFlight is the number of deliveries received and not handled yet.
+	inFlight atomic.Int64
+	// done is closed once the deliveries are all settled and the channel closed.
+	done chan struct{}
+}
+
+// startWorker opens a channel on conn limited to prefetch unacknowledged
+// deliveries, and handles the deliveries of c on it until it is cancelled or
+// the channel is closed.
+func startWorker(conn *amqp.Connection, c consumer, consumerTag string, prefetch int) (*worker, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, err
+	}
+	deliveries, err := consume(ch, c.queueName, consumerTag)
+	if err != nil {
+		ch.Close()
+		return nil, err
+	}
+	return runWorker(ch, consumerTag, deliveries, prefetch, c.handle, func() { conn.Close() }), nil
+}
+
+// runWorker handles deliveries, consumed on ch, in the background. closeConn
+// is called if the broker closes ch.
+func runWorker(ch workerChannel, consumerTag string, deliveries <-chan amqp.Delivery, prefetch int, handle func(amqp.Delivery), closeConn func()) *worker {
+	w := &worker{
+		ch:          ch,
+		consumerTag: consumerTag,
+		done:        make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		handleDeliveries(deliveries, prefetch, func(d amqp.Delivery) {
+			w.inFlight.Add(1)
+			defer w.inFlight.Add(-1)
+			handle(d)
+		})
+		if ch.IsClosed() {
+			// The channel was closed by the broker rather than cancelled, so
+			// close the connection for the supervisor to set everything up again.
+			if !w.cancelled.Load() {
+				closeConn()
+			}
+			return
+		}
+		// Close the channel only once every delivery was settled on it.
+		ch.Close()
+	}()
+	return w
+}
+
+// cancel stops the deliveries to the worker. The deliveries already received
+// are still handled.
+func (w *worker) cancel() error {
+	w.cancelled.Store(true)
+	if w.ch.IsClosed() {
+		return nil
+	}
+	return w.ch.Cancel(w.consumerTag, false)
+}
+
+// handleDeliveries passes every delivery to handle in its own goroutine, with
+// at most maxInFlight deliveries being handled at the same time. It returns
+// once deliveries is closed and every delivery was handled.
+func handleDeliveries(deliveries <-chan amqp.Delivery, maxInFlight int, handle func(amqp.Delivery)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxInFlight)
+	for d := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			handle(d)
+		}()
+	}
+	wg.Wait()
+}
+
+// abort requeues the deliveries that are still being handled and closes the
+// channel. The handlers can no longer settle them.
+func (w *worker) abort() (requeued int64, err error) {
+	requeued = w.inFlight.Load()
+	if w.ch.IsClosed() {
+		return 0, nil
+	}
+	// A delivery tag of 0 with multiple set covers every unacknowledged delivery.
+	if err := w.ch.Nack(0, true, true); err != nil {
+		return 0, err
+	}
+	return requeued, w.ch.Close()
+}
@@ -0,0 +1,113 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestHandleDeliveries(t *testing.T) {
+	t.Parallel()
+	const maxInFlight = 4
+
+	var inFlight, peak, handled atomic.Int32
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+		for range 20 {
+			deliveries <- amqp.Delivery{}
+		}
+	}()
+
+	handleDeliveries(deliveries, maxInFlight, func(amqp.Delivery) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		inFlight.Add(-1)
+		handled.Add(1)
+	})
+
+	// handleDeliveries returns only once every delivery was handled.
+	if n := handled.Load(); n != 20 {
+		t.Errorf("expected 20 handled deliveries, got %d", n)
+	}
+	if p := peak.Load(); p > maxInFlight {
+		t.Errorf("expected at most %d deliveries in flight, got %d", maxInFlight, p)
+	}
+}
+
+// creditAcknowledger gives back a prefetch credit when the delivery is settled.
+type creditAcknowledger struct {
+	credits chan struct{}
+}
+
+func (a creditAcknowledger) Ack(tag uint64, multiple bool) error {
+	<-a.credits
+	return nil
+}
+
+func (a creditAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	<-a.credits
+	return nil
+}
+
+func (a creditAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// BenchmarkWorkers measures the throughput of the consumer workers of a
+// queue, with a handler taking as long as a database insert. The broker is
+// simulated: a worker only receives a delivery when it has fewer than
+// prefetch unacknowledged ones, as with ch.Qos.
+func BenchmarkWorkers(b *testing.B) {
+	const insertLatency = 200 * time.Microsecond
+
+	for _, prefetch := range []int{1, 16} {
+		for _, workers := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("prefetch=%d/workers=%d", prefetch, workers), func(b *testing.B) {
+				queue := make(chan amqp.Delivery)
+				handle := func(d amqp.Delivery) {
+					time.Sleep(insertLatency)
+					d.Ack(false)
+				}
+
+				var wg sync.WaitGroup
+				for range workers {
+					credits := make(chan struct{}, prefetch)
+					deliveries := make(chan amqp.Delivery)
+					go func() {
+						defer close(deliveries)
+						for d := range queue {
+							credits <- struct{}{}
+							d.Acknowledger = creditAcknowledger{credits: credits}
+							deliveries <- d
+						}
+					}()
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						handleDeliveries(deliveries, prefetch, handle)
+					}()
+				}
+
+				b.ResetTimer()
+				start := time.Now()
+				for range b.N {
+					queue <- amqp.Delivery{}
+				}
+				close(queue)
+				wg.Wait()
+				b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "msgs/s")
+			})
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package presentation
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// AdminConfig holds the credentials of the endpoints wrapped by AdminAuth.
+type AdminConfig struct {
+	// Token is the bearer token expected from the callers. The admin
+	// endpoints are disabled when it is empty.
+	Token string
+}
+
+// NewAdminConfigFromEnv reads the admin token from ADMIN_TOKEN.
+func NewAdminConfigFromEnv() AdminConfig {
+	return AdminConfig{
+		Token: os.Getenv("ADMIN_TOKEN"),
+	}
+}
+
+// AdminAuth wraps next so that it can only be called with the admin token
+// in the Authorization header, as "Bearer <token>".
+func AdminAuth(cfg AdminConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.Token == "" {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAuth(t *testing.T) {
+	t.Parallel()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	testCases := map[string]struct {
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		"valid token": {
+			token:         "secret",
+			authorization: "Bearer secret",
+			wantStatus:    http.StatusNoContent,
+		},
+		"wrong token": {
+			token:         "secret",
+			authorization: "Bearer guess",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		"missing token": {
+			token:      "secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"basic scheme": {
+			token:         "secret",
+			authorization: "Basic secret",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		"disabled": {
+			authorization: "Bearer ",
+			wantStatus:    http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest("GET", "/admin/dlq/ctr_logs", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			AdminAuth(AdminConfig{Token: tc.token}, next).ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+}
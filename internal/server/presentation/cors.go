@@ -0,0 +1,68 @@
+package presentation
+
+import (
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig lists the web origins allowed to call the endpoints wrapped by CORS.
+type CORSConfig struct {
+	// AllowedOrigins are the allowed origins, such as "https://example.com".
+	// The single origin "*" allows every origin.
+	AllowedOrigins []string
+	// MaxAge is how long browsers may cache the result of a preflight request.
+	MaxAge time.Duration
+}
+
+// NewCORSConfigFromEnv reads the allowed origins from the comma-separated
+// CORS_ALLOWED_ORIGINS. No origin is allowed when it is unset.
+func NewCORSConfigFromEnv() CORSConfig {
+	cfg := CORSConfig{
+		MaxAge: 10 * time.Minute,
+	}
+	for _, origin := range strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.AllowedOrigins = append(cfg.AllowedOrigins, origin)
+		}
+	}
+	return cfg
+}
+
+// CORS wraps next so that it can be called from the allowed origins.
+//
+// Preflight requests are answered directly and never reach next.
+func CORS(cfg CORSConfig, next http.Handler) http.Handler {
+	allowAll := slices.Contains(cfg.AllowedOrigins, "*")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && (allowAll || slices.Contains(cfg.AllowedOrigins, origin))
+
+		// The response depends on the origin whether it is allowed or not, so
+		// that caches must not serve the response of one origin to another.
+		w.Header().Add("Vary", "Origin")
+		if allowed {
+			if allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
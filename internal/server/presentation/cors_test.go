@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, MaxAge: time.Minute}, next)
+
+	testCases := map[string]struct {
+		method          string
+		origin          string
+		preflight       bool
+		wantAllowOrigin string
+		wantMethods     string
+	}{
+		"allowed origin": {
+			method:          "POST",
+			origin:          "https://app.example.com",
+			wantAllowOrigin: "https://app.example.com",
+		},
+		"unknown origin": {
+			method: "POST",
+			origin: "https://evil.example.com",
+		},
+		"no origin": {
+			method: "POST",
+		},
+		"allowed preflight": {
+			method:          "OPTIONS",
+			origin:          "https://app.example.com",
+			preflight:       true,
+			wantAllowOrigin: "https://app.example.com",
+			wantMethods:     "POST, OPTIONS",
+		},
+		"unknown preflight": {
+			method:    "OPTIONS",
+			origin:    "https://evil.example.com",
+			preflight: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tc.method, "/ctr", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.preflight {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusNoContent {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNoContent)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Origin"); got != tc.wantAllowOrigin {
+				t.Errorf("Access-Control-Allow-Origin: got %q want %q", got, tc.wantAllowOrigin)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Methods"); got != tc.wantMethods {
+				t.Errorf("Access-Control-Allow-Methods: got %q want %q", got, tc.wantMethods)
+			}
+			if got := rr.Header().Values("Vary"); len(got) != 1 || got[0] != "Origin" {
+				t.Errorf("Vary: got %q want %q", got, "Origin")
+			}
+		})
+	}
+}
+
+func TestCORSAllowAll(t *testing.T) {
+	t.Parallel()
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"*"}}, http.NotFoundHandler())
+
+	req := httptest.NewRequest("POST", "/ctr", nil)
+	req.Header.Set("Origin", "https://any.example.com")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin: got %q want %q", got, "*")
+	}
+}
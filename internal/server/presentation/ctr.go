@@ -0,0 +1,200 @@
+package presentation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"log_service/internal/server/usecase"
+)
+
+// maxHttpCTRBodySize is the largest request body accepted by POST /ctr.
+const maxHttpCTRBodySize = 1 << 20
+
+// HttpCTRLogHandler receives CTR events sent by browsers and reports click-through rates.
+type HttpCTRLogHandler struct {
+	LogUseCase   usecase.IInsertCTRLogUseCase
+	StatsUseCase usecase.ICTRStatsUseCase
+	Validator    RequestValidator
+}
+
+// NewHttpCTRLogHandler creates a new instance of HttpCTRLogHandler with the given use cases.
+func NewHttpCTRLogHandler(
+	logUseCase usecase.IInsertCTRLogUseCase,
+	statsUseCase usecase.ICTRStatsUseCase,
+	validator RequestValidator,
+) *HttpCTRLogHandler {
+	return &HttpCTRLogHandler{
+		LogUseCase:   logUseCase,
+		StatsUseCase: statsUseCase,
+		Validator:    validator,
+	}
+}
+
+// HandleCTRLogCreate stores the CTR events sent in the request body.
+//
+// The body is either a single JSON object shaped like AMQPCTRLogRequest or a
+// JSON array of them. The Content-Type is not checked, so that the events can
+// be sent with navigator.sendBeacon, which uses text/plain for string bodies.
+// Events without createdAt are stamped with the time they were received. A
+// body larger than maxHttpCTRBodySize is rejected with 413 Request Entity Too
+// Large.
+func (h *HttpCTRLogHandler) HandleCTRLogCreate(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHttpCTRBodySize))
+	if isBodyTooLarge(err) {
+		http.Error(w, fmt.Sprintf("Request Entity Too Large: %v", err), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	reqs, err := ParseHttpCTRLog(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	for i := range reqs {
+		stampCTRLogRequest(&reqs[i], now)
+		if err := h.Validator.ValidateCTRLogRequest(reqs[i], now); err != nil {
+			http.Error(w, fmt.Sprintf("Bad Request: event %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// The events are inserted concurrently so that they can share batches.
+	errs := make([]error, len(reqs))
+	sem := make(chan struct{}, maxHttpBulkInFlight)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = h.LogUseCase.InsertCTRLog(r.Context(), &usecase.InsertCTRLogDto{
+				EventType: req.EventType,
+				CreatedAt: req.CreatedAt,
+				ObjectID:  req.ObjectID,
+			})
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			log.Printf("Failed to insert CTR log: %v", err)
+			http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ParseHttpCTRLog decodes a single CTR event or an array of CTR events.
+// Unknown fields are rejected.
+func ParseHttpCTRLog(body []byte) ([]AMQPCTRLogRequest, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []AMQPCTRLogRequest
+		if err := decodeStrict(body, &reqs); err != nil {
+			return nil, err
+		}
+		if len(reqs) == 0 {
+			return nil, fmt.Errorf("no events")
+		}
+		return reqs, nil
+	}
+
+	var req AMQPCTRLogRequest
+	if err := decodeStrict(body, &req); err != nil {
+		return nil, err
+	}
+	return []AMQPCTRLogRequest{req}, nil
+}
+
+// HandleCTRStats reports the impressions, clicks and click-through rate of the
+// top objects, in total and per time bucket.
+func (h *HttpCTRLogHandler) HandleCTRStats(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseHttpCTRStatsQuery(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	objects, err := h.StatsUseCase.GetCTRStats(r.Context(), query)
+	if errors.Is(err, usecase.ErrInvalidBucket) ||
+		errors.Is(err, usecase.ErrInvalidTimeRange) ||
+		errors.Is(err, usecase.ErrInvalidCTRStatsSort) ||
+		errors.Is(err, usecase.ErrInvalidLimit) {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get CTR stats: %v", err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]HttpCTRStatsResponse, len(objects))
+	for i, object := range objects {
+		buckets := make([]HttpCTRBucketStatsResponse, len(object.Buckets))
+		for j, bucket := range object.Buckets {
+			buckets[j] = HttpCTRBucketStatsResponse{
+				Start:       bucket.Start,
+				Impressions: bucket.Impressions,
+				Clicks:      bucket.Clicks,
+				CTR:         bucket.CTR,
+			}
+		}
+		response[i] = HttpCTRStatsResponse{
+			ObjectID:    object.ObjectID,
+			Impressions: object.Impressions,
+			Clicks:      object.Clicks,
+			CTR:         object.CTR,
+			Buckets:     buckets,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ParseHttpCTRStatsQuery reads the time range, bucket and ranking parameters
+// of GET /ctr/stats from the query string.
+//
+// Dates in "from" and "to" are formatted as RFC 3339.
+func ParseHttpCTRStatsQuery(r *http.Request) (*usecase.CTRStatsQueryDto, error) {
+	values := r.URL.Query()
+	query := &usecase.CTRStatsQueryDto{
+		Bucket: values.Get("bucket"),
+		Sort:   values.Get("sort"),
+	}
+
+	var err error
+	if from := values.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit <= 0 {
+			return nil, fmt.Errorf("invalid limit: %q", limit)
+		}
+	}
+	return query, nil
+}
@@ -0,0 +1,224 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/usecase"
+)
+
+func SetupCTRLogCreateTest(t *testing.T) (*gomock.Controller, *usecase.MockIInsertCTRLogUseCase, *HttpCTRLogHandler) {
+	ctrl := gomock.NewController(t)
+	mockInsertUseCase := usecase.NewMockIInsertCTRLogUseCase(ctrl)
+	mockStatsUseCase := usecase.NewMockICTRStatsUseCase(ctrl)
+	handler := NewHttpCTRLogHandler(mockInsertUseCase, mockStatsUseCase, RequestValidator{})
+	return ctrl, mockInsertUseCase, handler
+}
+
+func SetupCTRStatsTest(t *testing.T) (*gomock.Controller, *usecase.MockICTRStatsUseCase, *HttpCTRLogHandler) {
+	ctrl := gomock.NewController(t)
+	mockInsertUseCase := usecase.NewMockIInsertCTRLogUseCase(ctrl)
+	mockStatsUseCase := usecase.NewMockICTRStatsUseCase(ctrl)
+	handler := NewHttpCTRLogHandler(mockInsertUseCase, mockStatsUseCase, RequestValidator{})
+	return ctrl, mockStatsUseCase, handler
+}
+
+func TestHandleCTRLogCreate(t *testing.T) {
+	t.Parallel()
+	fixedTime := time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+
+	t.Run("Single Event", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupCTRLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertCTRLog(gomock.Any(), &usecase.InsertCTRLogDto{
+			EventType: "click",
+			CreatedAt: fixedTime,
+			ObjectID:  "banner-1",
+		}).Return(nil).Times(1)
+
+		body := `{"eventType":"click","objectId":"banner-1","createdAt":"2024-09-23T23:07:32Z"}`
+		req := httptest.NewRequest("POST", "/ctr", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusNoContent {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+		}
+	})
+
+	t.Run("Batched Beacon", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupCTRLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, dto *usecase.InsertCTRLogDto) error {
+				if dto.CreatedAt.IsZero() {
+					t.Errorf("InsertCTRLog() called without createdAt")
+				}
+				return nil
+			}).Times(2)
+
+		body := `[{"eventType":"impression","objectId":"banner-1"},{"eventType":"click","objectId":"banner-1"}]`
+		req := httptest.NewRequest("POST", "/ctr", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain;charset=UTF-8")
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusNoContent {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+		}
+	})
+
+	t.Run("Invalid Events", func(t *testing.T) {
+		t.Parallel()
+		for _, body := range []string{
+			`not json`,
+			`[]`,
+			`{"eventType":"hover","objectId":"banner-1"}`,
+			`[{"eventType":"click","objectId":"banner-1"},{"eventType":"click"}]`,
+			`{"eventType":"click","objectId":"` + strings.Repeat("x", 101) + `"}`,
+		} {
+			_, _, handler := SetupCTRLogCreateTest(t)
+
+			req := httptest.NewRequest("POST", "/ctr", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+
+			handler.HandleCTRLogCreate(rr, req)
+
+			if status := rr.Code; status != http.StatusBadRequest {
+				t.Errorf("%s: handler returned wrong status code: got %v want %v", body, status, http.StatusBadRequest)
+			}
+		}
+	})
+
+	t.Run("Body Too Large", func(t *testing.T) {
+		t.Parallel()
+		_, _, handler := SetupCTRLogCreateTest(t)
+
+		body := `{"eventType":"click","objectId":"` + strings.Repeat("x", maxHttpCTRBodySize) + `"}`
+		req := httptest.NewRequest("POST", "/ctr", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusRequestEntityTooLarge {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusRequestEntityTooLarge)
+		}
+	})
+
+	t.Run("InsertCTRLog Failure", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupCTRLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).Return(errors.New("failed to insert CTR log")).Times(1)
+
+		req := httptest.NewRequest("POST", "/ctr", strings.NewReader(`{"eventType":"click","objectId":"banner-1"}`))
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusInternalServerError {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusInternalServerError)
+		}
+	})
+}
+
+func TestHandleCTRStats(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		_, mockStatsUseCase, handler := SetupCTRStatsTest(t)
+
+		mockStatsUseCase.EXPECT().GetCTRStats(gomock.Any(), &usecase.CTRStatsQueryDto{
+			From:   from,
+			To:     from.Add(24 * time.Hour),
+			Bucket: "day",
+			Sort:   "ctr",
+			Limit:  5,
+		}).Return([]*usecase.CTRObjectStatsDto{
+			{
+				ObjectID:    "banner-1",
+				CTRStatsDto: usecase.CTRStatsDto{Impressions: 4, Clicks: 1, CTR: 0.25},
+				Buckets: []usecase.CTRBucketStatsDto{
+					{Start: from, CTRStatsDto: usecase.CTRStatsDto{Impressions: 4, Clicks: 1, CTR: 0.25}},
+				},
+			},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest("GET", "/ctr/stats?from=2024-09-23T00:00:00Z&to=2024-09-24T00:00:00Z&bucket=day&sort=ctr&limit=5", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRStats(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+		var response []HttpCTRStatsResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+		if len(response) != 1 || response[0].ObjectID != "banner-1" || response[0].CTR != 0.25 || len(response[0].Buckets) != 1 {
+			t.Errorf("handler returned unexpected body: %+v", response)
+		}
+	})
+
+	t.Run("Invalid Query", func(t *testing.T) {
+		t.Parallel()
+		for _, rawQuery := range []string{"from=yesterday", "to=2024", "limit=-1", "limit=ten"} {
+			_, _, handler := SetupCTRStatsTest(t)
+
+			req := httptest.NewRequest("GET", "/ctr/stats?"+rawQuery, nil)
+			rr := httptest.NewRecorder()
+
+			handler.HandleCTRStats(rr, req)
+
+			if status := rr.Code; status != http.StatusBadRequest {
+				t.Errorf("%s: handler returned wrong status code: got %v want %v", rawQuery, status, http.StatusBadRequest)
+			}
+		}
+	})
+
+	t.Run("Rejected Query", func(t *testing.T) {
+		t.Parallel()
+		_, mockStatsUseCase, handler := SetupCTRStatsTest(t)
+		mockStatsUseCase.EXPECT().GetCTRStats(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidBucket).Times(1)
+
+		req := httptest.NewRequest("GET", "/ctr/stats?bucket=week", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRStats(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("GetCTRStats Failure", func(t *testing.T) {
+		t.Parallel()
+		_, mockStatsUseCase, handler := SetupCTRStatsTest(t)
+		mockStatsUseCase.EXPECT().GetCTRStats(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to count")).Times(1)
+
+		req := httptest.NewRequest("GET", "/ctr/stats", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleCTRStats(rr, req)
+
+		if status := rr.Code; status != http.StatusInternalServerError {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+)
+
+// maxHttpDeadLetterSettleBodySize is the largest request body accepted when
+// replaying or purging dead letters.
+const maxHttpDeadLetterSettleBodySize = 1 << 20
+
+type HttpDeadLetterHandler struct {
+	UseCase usecase.IDeadLetterUseCase
+}
+
+func NewHttpDeadLetterHandler(useCase usecase.IDeadLetterUseCase) *HttpDeadLetterHandler {
+	return &HttpDeadLetterHandler{
+		UseCase: useCase,
+	}
+}
+
+// HandleDeadLetterList lists the oldest messages of the dead-letter queue of
+// the queue in the path. The number of messages is set by "limit".
+func (h *HttpDeadLetterHandler) HandleDeadLetterList(w http.ResponseWriter, r *http.Request) {
+	queue := r.PathValue("queue")
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, fmt.Sprintf("Bad Request: invalid limit %q", v), http.StatusBadRequest)
+			return
+		}
+	}
+
+	list, err := h.UseCase.ListDeadLetters(r.Context(), queue, limit)
+	if err != nil {
+		writeDeadLetterError(w, "list", err)
+		return
+	}
+
+	res := HttpDeadLetterListResponse{
+		Queue:       queue,
+		DeadLetters: make([]HttpDeadLetterResponse, len(list.DeadLetters)),
+		Total:       list.Total,
+	}
+	for i, dl := range list.DeadLetters {
+		res.DeadLetters[i] = newHttpDeadLetterResponse(dl)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// HandleDeadLetterGet returns a dead letter without removing it from its queue.
+func (h *HttpDeadLetterHandler) HandleDeadLetterGet(w http.ResponseWriter, r *http.Request) {
+	dl, err := h.UseCase.GetDeadLetter(r.Context(), r.PathValue("queue"), r.PathValue("id"))
+	if err != nil {
+		writeDeadLetterError(w, "get", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHttpDeadLetterResponse(dl))
+}
+
+// HandleDeadLetterReplay publishes the selected dead letters back to the
+// queue in the path.
+func (h *HttpDeadLetterHandler) HandleDeadLetterReplay(w http.ResponseWriter, r *http.Request) {
+	h.handleSettle(w, r, "replay", h.UseCase.ReplayDeadLetters)
+}
+
+// HandleDeadLetterPurge deletes the selected dead letters.
+func (h *HttpDeadLetterHandler) HandleDeadLetterPurge(w http.ResponseWriter, r *http.Request) {
+	h.handleSettle(w, r, "purge", h.UseCase.PurgeDeadLetters)
+}
+
+func (h *HttpDeadLetterHandler) handleSettle(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	settle func(ctx context.Context, queue string, ids []string) (int, error),
+) {
+	var req HttpDeadLetterSettleRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxHttpDeadLetterSettleBodySize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.All == (len(req.IDs) > 0) {
+		http.Error(w, `Bad Request: exactly one of "ids" and "all" must be set`, http.StatusBadRequest)
+		return
+	}
+
+	count, err := settle(r.Context(), r.PathValue("queue"), req.IDs)
+	if err != nil {
+		writeDeadLetterError(w, op, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, HttpDeadLetterSettleResponse{Count: count})
+}
+
+// writeDeadLetterError writes the response of a failed dead letter operation.
+func writeDeadLetterError(w http.ResponseWriter, op string, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidPageSize):
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrUnknownQueue), errors.Is(err, domain.ErrDeadLetterNotFound):
+		http.Error(w, fmt.Sprintf("Not Found: %v", err), http.StatusNotFound)
+	default:
+		log.Printf("Failed to %s dead letters: %v", op, err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func newHttpDeadLetterResponse(dto *usecase.DeadLetterDto) HttpDeadLetterResponse {
+	body := json.RawMessage(dto.Body)
+	if !json.Valid(dto.Body) {
+		body, _ = json.Marshal(string(dto.Body))
+	}
+	return HttpDeadLetterResponse{
+		ID:             dto.ID,
+		Queue:          dto.Queue,
+		Reason:         dto.Reason,
+		DeadLetteredAt: dto.DeadLetteredAt,
+		Retries:        dto.Retries,
+		ContentType:    dto.ContentType,
+		Body:           body,
+	}
+}
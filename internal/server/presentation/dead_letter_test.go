@@ -0,0 +1,238 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+)
+
+// newDeadLetterMux routes the admin endpoints to a handler using the given use case.
+func newDeadLetterMux(uc usecase.IDeadLetterUseCase) *http.ServeMux {
+	h := NewHttpDeadLetterHandler(uc)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/dlq/{queue}", h.HandleDeadLetterList)
+	mux.HandleFunc("GET /admin/dlq/{queue}/{id}", h.HandleDeadLetterGet)
+	mux.HandleFunc("POST /admin/dlq/{queue}/replay", h.HandleDeadLetterReplay)
+	mux.HandleFunc("POST /admin/dlq/{queue}/purge", h.HandleDeadLetterPurge)
+	return mux
+}
+
+func TestHandleDeadLetterList(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+
+	testCases := map[string]struct {
+		url        string
+		mockFunc   func(*usecase.MockIDeadLetterUseCase)
+		wantStatus int
+		wantBody   *HttpDeadLetterListResponse
+	}{
+		"Success": {
+			url: "/admin/dlq/ctr_logs?limit=2",
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "ctr_logs", 2).Return(&usecase.DeadLetterListDto{
+					DeadLetters: []*usecase.DeadLetterDto{
+						{ID: "msg-1", Queue: "ctr_logs", Reason: "failed to insert CTR log after 5 retries: db error", DeadLetteredAt: at, Retries: 5, Body: []byte(`{"eventType":"click"}`)},
+						{ID: "msg-2", Queue: "ctr_logs", Reason: "invalid CTR log request: EOF", DeadLetteredAt: at, Body: []byte(`not json`)},
+					},
+					Total: 7,
+				}, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: &HttpDeadLetterListResponse{
+				Queue: "ctr_logs",
+				DeadLetters: []HttpDeadLetterResponse{
+					{ID: "msg-1", Queue: "ctr_logs", Reason: "failed to insert CTR log after 5 retries: db error", DeadLetteredAt: at, Retries: 5, Body: json.RawMessage(`{"eventType":"click"}`)},
+					{ID: "msg-2", Queue: "ctr_logs", Reason: "invalid CTR log request: EOF", DeadLetteredAt: at, Body: json.RawMessage(`"not json"`)},
+				},
+				Total: 7,
+			},
+		},
+		"Invalid Limit": {
+			url:        "/admin/dlq/ctr_logs?limit=ten",
+			mockFunc:   func(m *usecase.MockIDeadLetterUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Limit Out Of Range": {
+			url: "/admin/dlq/ctr_logs?limit=100000",
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "ctr_logs", 100000).Return(nil, usecase.ErrInvalidPageSize).Times(1)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Unknown Queue": {
+			url: "/admin/dlq/unknown",
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "unknown", 0).Return(nil, domain.ErrUnknownQueue).Times(1)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		"Internal Error": {
+			url: "/admin/dlq/ctr_logs",
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "ctr_logs", 0).Return(nil, errors.New("channel closed")).Times(1)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockUseCase := usecase.NewMockIDeadLetterUseCase(ctrl)
+			tc.mockFunc(mockUseCase)
+
+			rec := httptest.NewRecorder()
+			newDeadLetterMux(mockUseCase).ServeHTTP(rec, httptest.NewRequest("GET", tc.url, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+			if tc.wantBody == nil {
+				return
+			}
+			var got HttpDeadLetterListResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(*tc.wantBody, got); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleDeadLetterGet(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		mockErr    error
+		wantStatus int
+	}{
+		"Success":   {wantStatus: http.StatusOK},
+		"Not Found": {mockErr: domain.ErrDeadLetterNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockUseCase := usecase.NewMockIDeadLetterUseCase(ctrl)
+			var dto *usecase.DeadLetterDto
+			if tc.mockErr == nil {
+				dto = &usecase.DeadLetterDto{ID: "msg-1", Queue: "ctr_logs", Body: []byte(`{}`)}
+			}
+			mockUseCase.EXPECT().GetDeadLetter(gomock.Any(), "ctr_logs", "msg-1").Return(dto, tc.mockErr).Times(1)
+
+			rec := httptest.NewRecorder()
+			newDeadLetterMux(mockUseCase).ServeHTTP(rec, httptest.NewRequest("GET", "/admin/dlq/ctr_logs/msg-1", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandleDeadLetterSettle(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		url        string
+		body       string
+		mockFunc   func(*usecase.MockIDeadLetterUseCase)
+		wantStatus int
+		wantCount  int
+	}{
+		"Replay By ID": {
+			url:  "/admin/dlq/ctr_logs/replay",
+			body: `{"ids":["msg-1","msg-2"]}`,
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ReplayDeadLetters(gomock.Any(), "ctr_logs", []string{"msg-1", "msg-2"}).Return(2, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+			wantCount:  2,
+		},
+		"Replay All": {
+			url:  "/admin/dlq/ctr_logs/replay",
+			body: `{"all":true}`,
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().ReplayDeadLetters(gomock.Any(), "ctr_logs", nil).Return(7, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+			wantCount:  7,
+		},
+		"Purge All": {
+			url:  "/admin/dlq/ctr_logs/purge",
+			body: `{"all":true}`,
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().PurgeDeadLetters(gomock.Any(), "ctr_logs", nil).Return(3, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+			wantCount:  3,
+		},
+		"Empty Selection": {
+			url:        "/admin/dlq/ctr_logs/purge",
+			body:       `{}`,
+			mockFunc:   func(m *usecase.MockIDeadLetterUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Both IDs And All": {
+			url:        "/admin/dlq/ctr_logs/replay",
+			body:       `{"ids":["msg-1"],"all":true}`,
+			mockFunc:   func(m *usecase.MockIDeadLetterUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Invalid Body": {
+			url:        "/admin/dlq/ctr_logs/replay",
+			body:       `ids`,
+			mockFunc:   func(m *usecase.MockIDeadLetterUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Unknown Queue": {
+			url:  "/admin/dlq/unknown/purge",
+			body: `{"all":true}`,
+			mockFunc: func(m *usecase.MockIDeadLetterUseCase) {
+				m.EXPECT().PurgeDeadLetters(gomock.Any(), "unknown", nil).Return(0, domain.ErrUnknownQueue).Times(1)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockUseCase := usecase.NewMockIDeadLetterUseCase(ctrl)
+			tc.mockFunc(mockUseCase)
+
+			rec := httptest.NewRecorder()
+			newDeadLetterMux(mockUseCase).ServeHTTP(rec, httptest.NewRequest("POST", tc.url, strings.NewReader(tc.body)))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var got HttpDeadLetterSettleResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Count != tc.wantCount {
+				t.Errorf("expected count %d, got %d", tc.wantCount, got.Count)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout bounds the time spent by every health check.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck reports whether a dependency of the server is usable.
+type HealthCheck func(ctx context.Context) error
+
+type HttpHealthHandler struct {
+	Checks map[string]HealthCheck
+}
+
+func NewHttpHealthHandler(checks map[string]HealthCheck) *HttpHealthHandler {
+	return &HttpHealthHandler{
+		Checks: checks,
+	}
+}
+
+// HandleHealth runs every check concurrently. It responds with 200 when all
+// of them pass, and with 503 otherwise, along with the result of each check.
+func (h *HttpHealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	res := HttpHealthResponse{
+		Status: HealthStatusOK,
+		Checks: make(map[string]string, len(h.Checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := HealthStatusOK
+			if err := check(ctx); err != nil {
+				result = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Checks[name] = result
+			if result != HealthStatusOK {
+				res.Status = HealthStatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	statusCode := http.StatusOK
+	if res.Status != HealthStatusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, res)
+}
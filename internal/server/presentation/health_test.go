@@ -0,0 +1,60 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandleHealth(t *testing.T) {
+	t.Parallel()
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("not connected to RabbitMQ") }
+
+	testCases := map[string]struct {
+		checks     map[string]HealthCheck
+		wantStatus int
+		wantBody   HttpHealthResponse
+	}{
+		"Healthy": {
+			checks:     map[string]HealthCheck{"mysql": ok, "rabbitmq": ok},
+			wantStatus: http.StatusOK,
+			wantBody: HttpHealthResponse{
+				Status: HealthStatusOK,
+				Checks: map[string]string{"mysql": HealthStatusOK, "rabbitmq": HealthStatusOK},
+			},
+		},
+		"Broker Down": {
+			checks:     map[string]HealthCheck{"mysql": ok, "rabbitmq": down},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: HttpHealthResponse{
+				Status: HealthStatusUnavailable,
+				Checks: map[string]string{"mysql": HealthStatusOK, "rabbitmq": "not connected to RabbitMQ"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			NewHttpHealthHandler(tc.checks).HandleHealth(rec, httptest.NewRequest("GET", "/healthz", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+			var got HttpHealthResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantBody, got); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,84 +1,170 @@
 package presentation
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"log_service/internal/server/domain"
 	"log_service/internal/server/usecase"
 	"log_service/internal/utils"
 )
 
+const (
+	ndjsonContentType = "application/x-ndjson"
+	// attributeParamPrefix prefixes the query parameters filtering logs by attribute.
+	attributeParamPrefix = "attr."
+	// maxHttpLogBodySize is the largest request body accepted by POST /logs.
+	maxHttpLogBodySize = 10 << 20
+	// maxHttpBulkInFlight bounds the number of logs of an NDJSON request
+	// that are inserted concurrently.
+	maxHttpBulkInFlight = 64
+)
+
+// IAMQPPublisher publishes AMQP messages. It is implemented by *amqp.Channel.
+type IAMQPPublisher interface {
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
 type AMQPLogHandler struct {
-	LogUseCase usecase.IInsertLogUseCase
-	Channel    *amqp.Channel
+	LogUseCase  usecase.IInsertLogUseCase
+	Channel     IAMQPPublisher
+	Validator   RequestValidator
+	DeadLetters IDeadLetterPublisher
 }
 
 type AMQPCTRLogHandler struct {
-	LogUseCase usecase.IInsertCTRLogUseCase
-	Channel    *amqp.Channel
+	LogUseCase  usecase.IInsertCTRLogUseCase
+	Channel     IAMQPPublisher
+	Validator   RequestValidator
+	Retrier     IRetryPublisher
+	RetryPolicy RetryPolicy
 }
 
 type HttpLogHandler struct {
-	ListUseCase usecase.IListLogsUseCase
+	ListUseCase   usecase.IListLogsUseCase
+	GetUseCase    usecase.IGetLogUseCase
+	InsertUseCase usecase.IInsertLogUseCase
+	StreamUseCase usecase.IStreamLogsUseCase
+	Validator     RequestValidator
 }
 
-func NewAMQPLogHandler(logUseCase usecase.IInsertLogUseCase, ch *amqp.Channel) *AMQPLogHandler {
+func NewAMQPLogHandler(
+	logUseCase usecase.IInsertLogUseCase,
+	ch IAMQPPublisher,
+	validator RequestValidator,
+	deadLetters IDeadLetterPublisher,
+) *AMQPLogHandler {
 	return &AMQPLogHandler{
-		LogUseCase: logUseCase,
-		Channel:    ch,
+		LogUseCase:  logUseCase,
+		Channel:     ch,
+		Validator:   validator,
+		DeadLetters: deadLetters,
 	}
 }
 
-func NewAMQPCTRLogHandler(logUseCase usecase.IInsertCTRLogUseCase, ch *amqp.Channel) *AMQPCTRLogHandler {
+func NewAMQPCTRLogHandler(
+	logUseCase usecase.IInsertCTRLogUseCase,
+	ch IAMQPPublisher,
+	validator RequestValidator,
+	retrier IRetryPublisher,
+	retryPolicy RetryPolicy,
+) *AMQPCTRLogHandler {
 	return &AMQPCTRLogHandler{
-		LogUseCase: logUseCase,
-		Channel:    ch,
+		LogUseCase:  logUseCase,
+		Channel:     ch,
+		Validator:   validator,
+		Retrier:     retrier,
+		RetryPolicy: retryPolicy,
 	}
 }
 
-func NewHttpLogHandler(listUseCase usecase.IListLogsUseCase) *HttpLogHandler {
+func NewHttpLogHandler(
+	listUseCase usecase.IListLogsUseCase,
+	getUseCase usecase.IGetLogUseCase,
+	insertUseCase usecase.IInsertLogUseCase,
+	streamUseCase usecase.IStreamLogsUseCase,
+	validator RequestValidator,
+) *HttpLogHandler {
 	return &HttpLogHandler{
-		ListUseCase: listUseCase,
+		ListUseCase:   listUseCase,
+		GetUseCase:    getUseCase,
+		InsertUseCase: insertUseCase,
+		StreamUseCase: streamUseCase,
+		Validator:     validator,
 	}
 }
 
 func (h *AMQPLogHandler) HandleLog(msg amqp.Delivery) {
+	// The result is always reported to the producer through the reply queue,
+	// so the message itself is acknowledged regardless of the outcome.
+	defer msg.Ack(false)
+
 	req, err := ParseAMQPLog(msg)
+	if err == nil {
+		err = h.Validator.ValidateLogRequest(req, time.Now())
+	}
 	if err != nil {
-		h.SendResponse(utils.INVALID_ARGUMENT, fmt.Sprintf("Failed to parse log request: %v", err), msg.ReplyTo, msg.CorrelationId)
+		h.deadLetter(msg, fmt.Sprintf("invalid log request: %v", err))
+		h.sendResponse(&AmqpLogResponse{
+			StatusCode: utils.INVALID_ARGUMENT,
+			Message:    fmt.Sprintf("Invalid log request: %v", err),
+			Errors:     fieldErrors(err),
+		}, msg.ReplyTo, msg.CorrelationId)
 		return
 	}
-	logDto := &usecase.InsertLogDto{
-		LogLevel:           req.LogLevel,
-		Date:               req.Date,
-		DestinationService: req.DestinationService,
-		SourceService:      req.SourceService,
-		RequestType:        req.RequestType,
-		Content:            req.Content,
+	id, err := h.LogUseCase.InsertLog(context.Background(), newInsertLogDto(req))
+	if isInvalidLogError(err) {
+		h.deadLetter(msg, fmt.Sprintf("invalid log request: %v", err))
+		h.SendResponse(utils.INVALID_ARGUMENT, fmt.Sprintf("Invalid log request: %v", err), "", msg.ReplyTo, msg.CorrelationId)
+		return
 	}
-	err = h.LogUseCase.InsertLog(context.Background(), logDto)
 	if err != nil {
-		h.SendResponse(utils.INTERNAL, fmt.Sprintf("Failed to insert log: %v", err), msg.ReplyTo, msg.CorrelationId)
+		h.deadLetter(msg, fmt.Sprintf("failed to insert log: %v", err))
+		h.SendResponse(utils.INTERNAL, fmt.Sprintf("Failed to insert log: %v", err), "", msg.ReplyTo, msg.CorrelationId)
 		return
 	}
 
-	h.SendResponse(utils.OK, "OK", msg.ReplyTo, msg.CorrelationId)
+	h.SendResponse(utils.OK, "OK", id, msg.ReplyTo, msg.CorrelationId)
+}
+
+// deadLetter copies msg to the dead-letter queue with the given reason, so
+// that a log that could not be stored is kept even when its producer did not
+// wait for the reply, as pkg/logger does. Service logs are not retried.
+func (h *AMQPLogHandler) deadLetter(msg amqp.Delivery, reason string) {
+	if err := h.DeadLetters.PublishDeadLetter(context.Background(), msg, reason); err != nil {
+		log.Println("failed to dead-letter log:", err)
+	}
 }
 
 // TODO: [Server] Improve the current RPC Implementation to reduct frontend delays
 // https://github.com/okuda-seminar/log_service/issues/85
 func (h *AMQPCTRLogHandler) HandleCTRLog(msg amqp.Delivery) {
 	req, err := ParseAMQPCTRLog(msg)
+	if err == nil {
+		now := time.Now()
+		stampCTRLogRequest(&req, now)
+		err = h.Validator.ValidateCTRLogRequest(req, now)
+	}
 	if err != nil {
-		log.Println("failed to parse CTR log request:", err)
-		// In case of invalid request, we should nack the message without requeue
-		msg.Nack(false, false)
+		log.Println("invalid CTR log request:", err)
+		// An invalid request never succeeds, so it is dead-lettered right away,
+		// and dropped if even that fails.
+		if err := h.deadLetter(msg, fmt.Sprintf("invalid CTR log request: %v", err)); err != nil {
+			msg.Nack(false, false)
+		}
 		return
 	}
 	logDto := &usecase.InsertCTRLogDto{
@@ -89,8 +175,7 @@ func (h *AMQPCTRLogHandler) HandleCTRLog(msg amqp.Delivery) {
 	err = h.LogUseCase.InsertCTRLog(context.Background(), logDto)
 	if err != nil {
 		log.Println("failed to insert CTR log:", err)
-		// In case of internal server error, we should nack the message with requeue
-		msg.Nack(false, true)
+		h.retry(msg, err)
 		return
 	}
 
@@ -98,32 +183,188 @@ func (h *AMQPCTRLogHandler) HandleCTRLog(msg amqp.Delivery) {
 	msg.Ack(false)
 }
 
+// retry schedules msg to be redelivered after a backoff delay, or
+// dead-letters it once the retries of the policy are exhausted.
+func (h *AMQPCTRLogHandler) retry(msg amqp.Delivery, cause error) {
+	retries := h.Retrier.Retries(msg)
+	if retries >= h.RetryPolicy.MaxRetries {
+		if err := h.deadLetter(msg, fmt.Sprintf("failed to insert CTR log after %d retries: %v", retries, cause)); err != nil {
+			msg.Nack(false, true)
+		}
+		return
+	}
+	if err := h.Retrier.PublishRetry(context.Background(), msg, h.RetryPolicy.Backoff(retries)); err != nil {
+		log.Println("failed to schedule CTR log retry:", err)
+		// Fall back to an immediate redelivery rather than losing the message.
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
+
+// deadLetter moves msg to the dead-letter queue with the given reason. msg is
+// left unsettled if it could not be published.
+func (h *AMQPCTRLogHandler) deadLetter(msg amqp.Delivery, reason string) error {
+	if err := h.Retrier.PublishDeadLetter(context.Background(), msg, reason); err != nil {
+		log.Println("failed to dead-letter CTR log:", err)
+		return err
+	}
+	msg.Ack(false)
+	return nil
+}
+
+// newInsertLogDto converts a log request received over AMQP or HTTP into the
+// input of IInsertLogUseCase.
+func newInsertLogDto(req AMQPLogRequest) *usecase.InsertLogDto {
+	return &usecase.InsertLogDto{
+		LogLevel:           req.LogLevel,
+		Date:               req.Date,
+		DestinationService: req.DestinationService,
+		SourceService:      req.SourceService,
+		RequestType:        req.RequestType,
+		Content:            req.Content,
+		Attributes:         req.Attributes,
+		TraceID:            req.TraceID,
+		SpanID:             req.SpanID,
+		ParentSpanID:       req.ParentSpanID,
+	}
+}
+
+// isBodyTooLarge reports whether err was caused by a request body exceeding
+// the limit of its http.MaxBytesReader.
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// isInvalidLogError reports whether err was caused by an invalid log request
+// rather than by a failure of the server.
+func isInvalidLogError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidSeverity) ||
+		errors.Is(err, domain.ErrInvalidTraceID) ||
+		errors.Is(err, domain.ErrInvalidSpanID)
+}
+
+// newHttpLogListResponse converts a listed log into its HTTP representation.
+func newHttpLogListResponse(dto *usecase.ListLogDto) HttpLogListResponse {
+	return HttpLogListResponse{
+		ID:                 dto.ID,
+		LogLevel:           dto.LogLevel,
+		Date:               dto.Date,
+		DestinationService: dto.DestinationService,
+		SourceService:      dto.SourceService,
+		RequestType:        dto.RequestType,
+		Content:            dto.Content,
+		Attributes:         dto.Attributes,
+		TraceID:            dto.TraceID,
+		SpanID:             dto.SpanID,
+		ParentSpanID:       dto.ParentSpanID,
+	}
+}
+
+// ParseAMQPLog decodes the log request of msg and fills its trace context
+// from the traceparent header. Unknown fields are rejected.
 func ParseAMQPLog(msg amqp.Delivery) (AMQPLogRequest, error) {
 	var req AMQPLogRequest
 
-	decoder := json.NewDecoder(bytes.NewReader(msg.Body))
-	err := decoder.Decode(&req)
-	if err != nil {
+	if err := decodeStrict(msg.Body, &req); err != nil {
 		return req, err
 	}
+	applyTraceparent(&req, amqpTraceparent(msg))
 	return req, nil
 }
 
+// ParseAMQPCTRLog decodes the CTR event of msg. Unknown fields are rejected.
 func ParseAMQPCTRLog(msg amqp.Delivery) (AMQPCTRLogRequest, error) {
 	var req AMQPCTRLogRequest
 
-	decoder := json.NewDecoder(bytes.NewReader(msg.Body))
-	err := decoder.Decode(&req)
-	if err != nil {
-		return req, err
-	}
+	err := decodeStrict(msg.Body, &req)
 	return req, err
 }
 
-func (h *AMQPLogHandler) SendResponse(statusCode int, message, key, corrID string) {
-	res := &AmqpLogResponse{
+// ParseHttpLogListQuery reads the filters, sort order and pagination
+// parameters of GET /logs from the query string.
+//
+// "level" selects the logs of one level and "min_level" the logs of a level
+// at least as severe, so that min_level=WARN returns warnings, errors and
+// fatal logs. Both accept the aliases of domain.Severity.
+//
+// Dates in "from" and "to" are formatted as RFC 3339. Every "attr.<key>"
+// parameter requires the attribute <key> to equal its value, which is read as
+// a JSON number, boolean or string when it is one (attr.status=200 or
+// attr.id="200") and as a plain string otherwise (attr.user_id=u-1).
+func ParseHttpLogListQuery(r *http.Request) (*usecase.ListLogsQueryDto, error) {
+	values := r.URL.Query()
+	query := &usecase.ListLogsQueryDto{
+		LogLevel:           values.Get("level"),
+		MinLogLevel:        values.Get("min_level"),
+		SourceService:      values.Get("source_service"),
+		DestinationService: values.Get("destination_service"),
+		RequestType:        values.Get("request_type"),
+		Content:            values.Get("content"),
+		Order:              values.Get("order"),
+		Cursor:             values.Get("cursor"),
+	}
+
+	var err error
+	if from := values.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if pageSize := values.Get("page_size"); pageSize != "" {
+		if query.PageSize, err = strconv.Atoi(pageSize); err != nil || query.PageSize <= 0 {
+			return nil, fmt.Errorf("invalid page_size: %q", pageSize)
+		}
+	}
+	for param, vs := range values {
+		key, ok := strings.CutPrefix(param, attributeParamPrefix)
+		if !ok {
+			continue
+		}
+		if key == "" || len(vs) != 1 {
+			return nil, fmt.Errorf("invalid %s: expected a single attribute value", param)
+		}
+		if query.Attributes == nil {
+			query.Attributes = make(map[string]any)
+		}
+		query.Attributes[key] = parseAttributeValue(vs[0])
+	}
+	return query, nil
+}
+
+// parseAttributeValue reads v as a JSON scalar, falling back to the string itself.
+func parseAttributeValue(v string) any {
+	var value any
+	if err := json.Unmarshal([]byte(v), &value); err != nil {
+		return v
+	}
+	switch value.(type) {
+	case string, float64, bool:
+		return value
+	default:
+		return v
+	}
+}
+
+func (h *AMQPLogHandler) SendResponse(statusCode int, message, id, key, corrID string) {
+	h.sendResponse(&AmqpLogResponse{
 		StatusCode: statusCode,
 		Message:    message,
+		ID:         id,
+	}, key, corrID)
+}
+
+func (h *AMQPLogHandler) sendResponse(res *AmqpLogResponse, key, corrID string) {
+	// Producers that do not wait for the result, such as the asynchronous
+	// logger, publish without a reply queue.
+	if key == "" {
+		return
 	}
 	bytes, err := json.Marshal(res)
 	if err != nil {
@@ -140,12 +381,27 @@ func (h *AMQPLogHandler) SendResponse(statusCode int, message, key, corrID strin
 			Body:          bytes,
 		})
 	if err != nil {
-		panic(err)
+		// The producer times out waiting for the response, as it would if the
+		// response was lost on the way.
+		log.Printf("Failed to send log response: %v", err)
 	}
 }
 
 func (h *HttpLogHandler) HandleLogList(w http.ResponseWriter, r *http.Request) {
-	logs, err := h.ListUseCase.ListLogs(r.Context())
+	query, err := ParseHttpLogListQuery(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	logs, nextCursor, err := h.ListUseCase.ListLogs(r.Context(), query)
+	if errors.Is(err, usecase.ErrInvalidCursor) ||
+		errors.Is(err, domain.ErrInvalidSeverity) ||
+		errors.Is(err, usecase.ErrInvalidPageSize) ||
+		errors.Is(err, usecase.ErrInvalidSortOrder) {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to list logs: %v", err)
 		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
@@ -154,17 +410,13 @@ func (h *HttpLogHandler) HandleLogList(w http.ResponseWriter, r *http.Request) {
 
 	responseLogs := make([]HttpLogListResponse, len(logs))
 	for i, eachLog := range logs {
-		responseLogs[i] = HttpLogListResponse{
-			LogLevel:           eachLog.LogLevel,
-			Date:               eachLog.Date,
-			DestinationService: eachLog.DestinationService,
-			SourceService:      eachLog.SourceService,
-			RequestType:        eachLog.RequestType,
-			Content:            eachLog.Content,
-		}
+		responseLogs[i] = newHttpLogListResponse(eachLog)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if nextCursor != "" {
+		w.Header().Set(NextCursorHeader, nextCursor)
+	}
 	w.WriteHeader(http.StatusOK)
 
 	if err := json.NewEncoder(w).Encode(responseLogs); err != nil {
@@ -174,3 +426,199 @@ func (h *HttpLogHandler) HandleLogList(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+func (h *HttpLogHandler) HandleLogGet(w http.ResponseWriter, r *http.Request) {
+	eachLog, err := h.GetUseCase.GetLog(r.Context(), r.PathValue("id"))
+	if errors.Is(err, domain.ErrLogNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get log: %v", err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	responseLog := HttpLogGetResponse{
+		ID:                 eachLog.ID,
+		LogLevel:           eachLog.LogLevel,
+		Date:               eachLog.Date,
+		DestinationService: eachLog.DestinationService,
+		SourceService:      eachLog.SourceService,
+		RequestType:        eachLog.RequestType,
+		Content:            eachLog.Content,
+		Attributes:         eachLog.Attributes,
+		TraceID:            eachLog.TraceID,
+		SpanID:             eachLog.SpanID,
+		ParentSpanID:       eachLog.ParentSpanID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(responseLog); err != nil {
+		log.Printf("Failed to encode log: %v", err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleLogCreate stores the logs sent in the request body.
+//
+// The body is either a single JSON object shaped like AMQPLogRequest or, when
+// the Content-Type is application/x-ndjson, one such object per line. In bulk
+// mode every line gets its own status in the NDJSON response. A body larger
+// than maxHttpLogBodySize is rejected as a whole with 413 Request Entity Too
+// Large.
+func (h *HttpLogHandler) HandleLogCreate(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxHttpLogBodySize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == ndjsonContentType {
+		h.handleLogBulkCreate(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if isBodyTooLarge(err) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, HttpLogInsertResponse{
+			StatusCode: utils.INVALID_ARGUMENT,
+			Message:    fmt.Sprintf("Log request too large: %v", err),
+		})
+		return
+	}
+	var req AMQPLogRequest
+	if err == nil {
+		err = decodeStrict(body, &req)
+	}
+	if err == nil {
+		applyTraceparent(&req, r.Header.Get(TraceparentHeader))
+		err = h.Validator.ValidateLogRequest(req, time.Now())
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, HttpLogInsertResponse{
+			StatusCode: utils.INVALID_ARGUMENT,
+			Message:    fmt.Sprintf("Invalid log request: %v", err),
+			Errors:     fieldErrors(err),
+		})
+		return
+	}
+
+	id, err := h.InsertUseCase.InsertLog(r.Context(), newInsertLogDto(req))
+	if isInvalidLogError(err) {
+		writeJSON(w, http.StatusBadRequest, HttpLogInsertResponse{
+			StatusCode: utils.INVALID_ARGUMENT,
+			Message:    fmt.Sprintf("Invalid log request: %v", err),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to insert log: %v", err)
+		writeJSON(w, http.StatusInternalServerError, HttpLogInsertResponse{
+			StatusCode: utils.INTERNAL,
+			Message:    fmt.Sprintf("Failed to insert log: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, HttpLogInsertResponse{
+		StatusCode: utils.OK,
+		Message:    "OK",
+		ID:         id,
+	})
+}
+
+func (h *HttpLogHandler) handleLogBulkCreate(w http.ResponseWriter, r *http.Request) {
+	// Read the whole body first so that a malformed stream is rejected
+	// before anything is stored.
+	var lines []string
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(nil, maxHttpLogBodySize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); isBodyTooLarge(err) {
+		// The logs are not stored in part, so that the producer can split the
+		// request and send it again.
+		http.Error(w, fmt.Sprintf("Request Entity Too Large: %v", err), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// The logs are inserted concurrently so that they can share batches.
+	now := time.Now()
+	results := make([]*HttpLogBulkInsertResponse, len(lines))
+	sem := make(chan struct{}, maxHttpBulkInFlight)
+	var wg sync.WaitGroup
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		result := &HttpLogBulkInsertResponse{Line: i + 1}
+		results[i] = result
+
+		var req AMQPLogRequest
+		err := decodeStrict([]byte(line), &req)
+		if err == nil {
+			// The header applies to every log of the request.
+			applyTraceparent(&req, r.Header.Get(TraceparentHeader))
+			err = h.Validator.ValidateLogRequest(req, now)
+		}
+		if err != nil {
+			result.StatusCode = utils.INVALID_ARGUMENT
+			result.Message = fmt.Sprintf("Invalid log request: %v", err)
+			result.Errors = fieldErrors(err)
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			id, err := h.InsertUseCase.InsertLog(r.Context(), newInsertLogDto(req))
+			if isInvalidLogError(err) {
+				result.StatusCode = utils.INVALID_ARGUMENT
+				result.Message = fmt.Sprintf("Invalid log request: %v", err)
+				return
+			}
+			if err != nil {
+				log.Printf("Failed to insert log: %v", err)
+				result.StatusCode = utils.INTERNAL
+				result.Message = fmt.Sprintf("Failed to insert log: %v", err)
+				return
+			}
+			result.StatusCode = utils.OK
+			result.Message = "OK"
+			result.ID = id
+		}()
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if err := encoder.Encode(result); err != nil {
+			log.Printf("Failed to encode bulk insert result: %v", err)
+			return
+		}
+	}
+}
+
+// writeJSON writes v as the JSON body of a response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
@@ -9,6 +9,14 @@ type AMQPLogRequest struct {
 	DestinationService string    `json:"destination_service"`
 	RequestType        string    `json:"request_type"`
 	Content            string    `json:"content"`
+	// Attributes are structured key/values attached to the log.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// TraceID, SpanID and ParentSpanID place the log in a distributed trace,
+	// as W3C Trace Context IDs. When they are omitted, they are read from the
+	// traceparent header of the message or request, if any.
+	TraceID      string `json:"trace_id,omitempty"`
+	SpanID       string `json:"span_id,omitempty"`
+	ParentSpanID string `json:"parent_span_id,omitempty"`
 }
 
 type AMQPCTRLogRequest struct {
@@ -16,3 +24,11 @@ type AMQPCTRLogRequest struct {
 	ObjectID  string    `json:"objectId"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+// HttpDeadLetterSettleRequest selects the dead letters to replay or purge.
+// Exactly one of IDs and All must be set, so that all the messages of a
+// queue are never settled by mistake.
+type HttpDeadLetterSettleRequest struct {
+	IDs []string `json:"ids"`
+	All bool     `json:"all"`
+}
@@ -1,17 +1,153 @@
 package presentation
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// NextCursorHeader is the response header of GET /logs carrying the cursor
+// of the next page. It is omitted on the last page.
+const NextCursorHeader = "X-Next-Cursor"
 
 type AmqpLogResponse struct {
 	StatusCode int    `json:"status_code"`
 	Message    string `json:"message"`
+	ID         string `json:"id,omitempty"`
+	// Errors lists the invalid fields of a rejected request.
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
 type HttpLogListResponse struct {
-	LogLevel           string    `json:"log_level"`
-	Date               time.Time `json:"date"`
-	SourceService      string    `json:"source_service"`
-	DestinationService string    `json:"destination_service"`
-	RequestType        string    `json:"request_type"`
-	Content            string    `json:"content"`
+	ID                 string         `json:"id"`
+	LogLevel           string         `json:"log_level"`
+	Date               time.Time      `json:"date"`
+	SourceService      string         `json:"source_service"`
+	DestinationService string         `json:"destination_service"`
+	RequestType        string         `json:"request_type"`
+	Content            string         `json:"content"`
+	Attributes         map[string]any `json:"attributes,omitempty"`
+	TraceID            string         `json:"trace_id,omitempty"`
+	SpanID             string         `json:"span_id,omitempty"`
+	ParentSpanID       string         `json:"parent_span_id,omitempty"`
+}
+
+type HttpLogGetResponse struct {
+	ID                 string         `json:"id"`
+	LogLevel           string         `json:"log_level"`
+	Date               time.Time      `json:"date"`
+	SourceService      string         `json:"source_service"`
+	DestinationService string         `json:"destination_service"`
+	RequestType        string         `json:"request_type"`
+	Content            string         `json:"content"`
+	Attributes         map[string]any `json:"attributes,omitempty"`
+	TraceID            string         `json:"trace_id,omitempty"`
+	SpanID             string         `json:"span_id,omitempty"`
+	ParentSpanID       string         `json:"parent_span_id,omitempty"`
+}
+
+type HttpLogInsertResponse struct {
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+	ID         string `json:"id,omitempty"`
+	// Errors lists the invalid fields of a rejected request.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// HttpLogBulkInsertResponse is the result of one line of an NDJSON request to POST /logs.
+type HttpLogBulkInsertResponse struct {
+	// Line is the 1-based line number of the log in the request body.
+	Line       int    `json:"line"`
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+	ID         string `json:"id,omitempty"`
+	// Errors lists the invalid fields of a rejected log.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// HttpCTRStatsResponse is the statistics of one object returned by GET /ctr/stats.
+type HttpCTRStatsResponse struct {
+	ObjectID    string                       `json:"object_id"`
+	Impressions int64                        `json:"impressions"`
+	Clicks      int64                        `json:"clicks"`
+	CTR         float64                      `json:"ctr"`
+	Buckets     []HttpCTRBucketStatsResponse `json:"buckets"`
+}
+
+// HttpCTRBucketStatsResponse is the statistics of one object within one time bucket.
+type HttpCTRBucketStatsResponse struct {
+	Start       time.Time `json:"start"`
+	Impressions int64     `json:"impressions"`
+	Clicks      int64     `json:"clicks"`
+	CTR         float64   `json:"ctr"`
+}
+
+// HttpServiceGraphResponse is the dependency graph of the services returned by GET /services/graph.
+type HttpServiceGraphResponse struct {
+	Nodes []HttpServiceNodeResponse `json:"nodes"`
+	Edges []HttpServiceEdgeResponse `json:"edges"`
+}
+
+// HttpServiceNodeResponse is a service with the calls it received and sent.
+type HttpServiceNodeResponse struct {
+	Name      string `json:"name"`
+	CallsIn   int64  `json:"calls_in"`
+	CallsOut  int64  `json:"calls_out"`
+	ErrorsIn  int64  `json:"errors_in"`
+	ErrorsOut int64  `json:"errors_out"`
+}
+
+// HttpServiceEdgeResponse is the calls from one service to another.
+type HttpServiceEdgeResponse struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Calls       int64  `json:"calls"`
+	Errors      int64  `json:"errors"`
+	// Levels is the number of calls per log level.
+	Levels map[string]int64 `json:"levels"`
+}
+
+// HttpLogStreamDroppedResponse is the data of the "dropped" event of GET /logs/stream.
+type HttpLogStreamDroppedResponse struct {
+	// Count is the number of logs dropped since the previous event.
+	Count uint64 `json:"count"`
+}
+
+// HttpDeadLetterListResponse is the page of dead letters returned by GET /admin/dlq/{queue}.
+type HttpDeadLetterListResponse struct {
+	Queue       string                   `json:"queue"`
+	DeadLetters []HttpDeadLetterResponse `json:"dead_letters"`
+	// Total is the number of messages in the dead-letter queue.
+	Total int `json:"total"`
+}
+
+// HttpDeadLetterResponse is a message given up on by an AMQP handler.
+type HttpDeadLetterResponse struct {
+	ID    string `json:"id"`
+	Queue string `json:"queue"`
+	// Reason is the parse or insert error that caused the message to be dead-lettered.
+	Reason         string    `json:"reason"`
+	DeadLetteredAt time.Time `json:"dead_lettered_at"`
+	Retries        int       `json:"retries"`
+	ContentType    string    `json:"content_type,omitempty"`
+	// Body is the message body as is when it is valid JSON, and as a JSON
+	// string otherwise.
+	Body json.RawMessage `json:"body"`
+}
+
+// HttpDeadLetterSettleResponse is the result of replaying or purging dead letters.
+type HttpDeadLetterSettleResponse struct {
+	Count int `json:"count"`
+}
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HttpHealthResponse is the response of GET /healthz.
+type HttpHealthResponse struct {
+	// Status is HealthStatusOK when every check passed.
+	Status string `json:"status"`
+	// Checks is the result of every check, HealthStatusOK or the error.
+	Checks map[string]string `json:"checks"`
 }
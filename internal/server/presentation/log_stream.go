@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+)
+
+// logStreamHeartbeat is the interval at which a comment is sent on an idle
+// stream, so that proxies do not close the connection.
+const logStreamHeartbeat = 15 * time.Second
+
+// HandleLogStream streams the logs stored from now on as Server-Sent Events.
+//
+// It accepts the same filters as GET /logs; the sort order and pagination
+// parameters are ignored. Each log is sent as a "log" event whose data is
+// shaped like HttpLogListResponse. When the client does not keep up, logs are
+// dropped and a "dropped" event carrying their number is sent before the next log.
+func (h *HttpLogHandler) HandleLogStream(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseHttpLogListQuery(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.StreamUseCase.SubscribeLogs(query)
+	if errors.Is(err, domain.ErrInvalidSeverity) {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disable response buffering in nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(logStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case log, ok := <-sub.Logs():
+			if !ok {
+				return
+			}
+			if err := writeDroppedEvent(w, sub); err != nil {
+				return
+			}
+			if err := writeEvent(w, log.ID, "log", newHttpLogListResponse(log)); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := writeDroppedEvent(w, sub); err != nil {
+				return
+			}
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeDroppedEvent reports the logs dropped for sub since the last report, if any.
+func writeDroppedEvent(w io.Writer, sub *usecase.LogSubscription) error {
+	dropped := sub.TakeDropped()
+	if dropped == 0 {
+		return nil
+	}
+	return writeEvent(w, "", "dropped", HttpLogStreamDroppedResponse{Count: dropped})
+}
+
+// writeEvent writes a Server-Sent Event whose data is v encoded as JSON.
+func writeEvent(w io.Writer, id, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
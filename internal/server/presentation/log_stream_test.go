@@ -0,0 +1,144 @@
+package presentation
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+)
+
+func SetupLogStreamTest(t *testing.T) (*gomock.Controller, *usecase.MockIStreamLogsUseCase, *HttpLogHandler) {
+	ctrl := gomock.NewController(t)
+	mockStreamUseCase := usecase.NewMockIStreamLogsUseCase(ctrl)
+	handler := NewHttpLogHandler(usecase.NewMockIListLogsUseCase(ctrl), usecase.NewMockIGetLogUseCase(ctrl), usecase.NewMockIInsertLogUseCase(ctrl), mockStreamUseCase, RequestValidator{})
+	return ctrl, mockStreamUseCase, handler
+}
+
+func TestHandleLogStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stream", func(t *testing.T) {
+		t.Parallel()
+		_, mockStreamUseCase, handler := SetupLogStreamTest(t)
+		hub := usecase.NewLogHub()
+		subscribed := make(chan struct{})
+		mockStreamUseCase.EXPECT().SubscribeLogs(&usecase.ListLogsQueryDto{LogLevel: "ERROR"}).DoAndReturn(
+			func(query *usecase.ListLogsQueryDto) (*usecase.LogSubscription, error) {
+				defer close(subscribed)
+				return hub.Subscribe(domain.LogFilter{Severity: domain.SeverityError}, 10), nil
+			}).Times(1)
+
+		srv := httptest.NewServer(http.HandlerFunc(handler.HandleLogStream))
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/logs/stream?level=ERROR")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", res.StatusCode, http.StatusOK)
+		}
+		if got := res.Header.Get("Content-Type"); got != "text/event-stream" {
+			t.Errorf("handler returned wrong content type: got %v", got)
+		}
+
+		<-subscribed
+		hub.Publish(&domain.Log{ID: "log-1", LogLevel: "INFO"})
+		hub.Publish(&domain.Log{ID: "log-2", LogLevel: "ERROR", Content: "failed"})
+		hub.Close()
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events := readEvents(t, string(body))
+		if len(events) != 1 || events[0]["event"] != "log" || events[0]["id"] != "log-2" ||
+			!strings.Contains(events[0]["data"], `"content":"failed"`) {
+			t.Errorf("handler streamed unexpected events: %v", events)
+		}
+	})
+
+	t.Run("Slow Client", func(t *testing.T) {
+		t.Parallel()
+		_, mockStreamUseCase, handler := SetupLogStreamTest(t)
+		hub := usecase.NewLogHub()
+		sub := hub.Subscribe(domain.LogFilter{}, 1)
+		for _, id := range []string{"log-1", "log-2", "log-3"} {
+			hub.Publish(&domain.Log{ID: id})
+		}
+		hub.Close()
+		mockStreamUseCase.EXPECT().SubscribeLogs(gomock.Any()).Return(sub, nil).Times(1)
+
+		req := httptest.NewRequest("GET", "/logs/stream", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogStream(rr, req)
+
+		events := readEvents(t, rr.Body.String())
+		if len(events) != 2 ||
+			events[0]["event"] != "dropped" || events[0]["data"] != `{"count":2}` ||
+			events[1]["event"] != "log" || events[1]["id"] != "log-1" {
+			t.Errorf("handler streamed unexpected events: %v", events)
+		}
+	})
+
+	t.Run("Invalid Query", func(t *testing.T) {
+		t.Parallel()
+		_, _, handler := SetupLogStreamTest(t)
+
+		req := httptest.NewRequest("GET", "/logs/stream?from=yesterday", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogStream(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		t.Parallel()
+		_, mockStreamUseCase, handler := SetupLogStreamTest(t)
+		mockStreamUseCase.EXPECT().SubscribeLogs(&usecase.ListLogsQueryDto{MinLogLevel: "loud"}).Return(nil, domain.ErrInvalidSeverity).Times(1)
+
+		req := httptest.NewRequest("GET", "/logs/stream?min_level=loud", nil)
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogStream(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+	})
+}
+
+// readEvents parses a Server-Sent Events stream, skipping comments.
+func readEvents(t *testing.T, stream string) []map[string]string {
+	t.Helper()
+	var events []map[string]string
+	event := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(stream))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(event) > 0 {
+				events = append(events, event)
+				event = map[string]string{}
+			}
+		case strings.HasPrefix(line, ":"):
+		default:
+			field, value, _ := strings.Cut(line, ": ")
+			event[field] = value
+		}
+	}
+	return events
+}
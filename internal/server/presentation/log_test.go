@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 	"go.uber.org/mock/gomock"
 
+	"log_service/internal/server/domain"
 	"log_service/internal/server/usecase"
 	"log_service/internal/utils"
 )
@@ -67,6 +69,28 @@ func (m *MockDelivery) Nack(multiple, requeue bool) error {
 	return nil
 }
 
+// fakeAcknowledger records how a delivery was settled.
+type fakeAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked = true
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
 func TestNewAMQPLogHandler(t *testing.T) {
 	fixedTime := time.Date(2024, 9, 23, 23, 7, 32, 840757000, time.Local)
 	logRequest, msg := testMsg(t, fixedTime)
@@ -76,12 +100,14 @@ func TestNewAMQPLogHandler(t *testing.T) {
 		msg                amqp.Delivery
 		mockFunc           func(m *usecase.MockIInsertLogUseCase)
 		expectedStatusCode int
+		expectedID         string
+		expectDeadLetter   bool
 	}{
 		{
 			name: "success",
 			msg:  msg,
 			mockFunc: func(m *usecase.MockIInsertLogUseCase) {
-				m.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, gotRequest *usecase.InsertLogDto) error {
+				m.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, gotRequest *usecase.InsertLogDto) (string, error) {
 					convertedRequest := AMQPLogRequest{
 						LogLevel:           gotRequest.LogLevel,
 						Date:               gotRequest.Date,
@@ -91,22 +117,24 @@ func TestNewAMQPLogHandler(t *testing.T) {
 						Content:            gotRequest.Content,
 					}
 					testDiffLog(t, logRequest, convertedRequest)
-					return nil
+					return "log-id", nil
 				}).Times(1)
 			},
 			expectedStatusCode: utils.OK,
+			expectedID:         "log-id",
 		},
 		{
 			name:               "failed",
 			msg:                amqp.Delivery{},
 			mockFunc:           func(m *usecase.MockIInsertLogUseCase) {},
 			expectedStatusCode: utils.INVALID_ARGUMENT,
+			expectDeadLetter:   true,
 		},
 		{
 			name: "failed",
 			msg:  msg,
 			mockFunc: func(m *usecase.MockIInsertLogUseCase) {
-				m.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, gotRequest *usecase.InsertLogDto) error {
+				m.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, gotRequest *usecase.InsertLogDto) (string, error) {
 					convertedRequest := AMQPLogRequest{
 						LogLevel:           gotRequest.LogLevel,
 						Date:               gotRequest.Date,
@@ -116,10 +144,34 @@ func TestNewAMQPLogHandler(t *testing.T) {
 						Content:            gotRequest.Content,
 					}
 					testDiffLog(t, logRequest, convertedRequest)
-					return errors.New("failed to insert log.")
+					return "", errors.New("failed to insert log.")
 				}).Times(1)
 			},
 			expectedStatusCode: utils.INTERNAL,
+			expectDeadLetter:   true,
+		},
+		{
+			name:               "unknown field",
+			msg:                amqp.Delivery{Body: []byte(`{"content":"c","level":"INFO"}`)},
+			mockFunc:           func(m *usecase.MockIInsertLogUseCase) {},
+			expectedStatusCode: utils.INVALID_ARGUMENT,
+			expectDeadLetter:   true,
+		},
+		{
+			name:               "missing fields",
+			msg:                amqp.Delivery{Body: []byte(`{"content":"c"}`)},
+			mockFunc:           func(m *usecase.MockIInsertLogUseCase) {},
+			expectedStatusCode: utils.INVALID_ARGUMENT,
+			expectDeadLetter:   true,
+		},
+		{
+			name: "unknown level",
+			msg:  msg,
+			mockFunc: func(m *usecase.MockIInsertLogUseCase) {
+				m.EXPECT().InsertLog(gomock.Any(), gomock.Any()).Return("", domain.ErrInvalidSeverity).Times(1)
+			},
+			expectedStatusCode: utils.INVALID_ARGUMENT,
+			expectDeadLetter:   true,
 		},
 	}
 
@@ -129,10 +181,15 @@ func TestNewAMQPLogHandler(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockInsertUseCase := usecase.NewMockIInsertLogUseCase(ctrl)
-			handler := NewAMQPLogHandler(mockInsertUseCase, &amqp.Channel{})
+			mockDeadLetters := NewMockIDeadLetterPublisher(ctrl)
+			handler := NewAMQPLogHandler(mockInsertUseCase, &amqp.Channel{}, RequestValidator{}, mockDeadLetters)
 			tt.mockFunc(mockInsertUseCase)
+			if tt.expectDeadLetter {
+				mockDeadLetters.EXPECT().PublishDeadLetter(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
 
 			var patchResponseCode int
+			var patchResponseID string
 
 			// gomonkey cannot be used for parallel tests because it operates on shared resources.
 			patch := gomonkey.ApplyMethod(
@@ -152,20 +209,62 @@ func TestNewAMQPLogHandler(t *testing.T) {
 						return err
 					}
 					patchResponseCode = res.StatusCode
+					patchResponseID = res.ID
 					return nil
 				})
 
+			acknowledger := &fakeAcknowledger{}
+			tt.msg.Acknowledger = acknowledger
+			tt.msg.ReplyTo = "reply-queue"
+
 			handler.HandleLog(tt.msg)
 			t.Log(patchResponseCode)
 
 			if patchResponseCode != tt.expectedStatusCode {
 				t.Errorf("Expected %d, got %d", tt.expectedStatusCode, patchResponseCode)
 			}
+			if patchResponseID != tt.expectedID {
+				t.Errorf("Expected ID %q, got %q", tt.expectedID, patchResponseID)
+			}
+			if !acknowledger.acked {
+				t.Errorf("Expected the message to be acknowledged")
+			}
 			patch.Reset()
 		})
 	}
 }
 
+// recordingPublisher records the messages published through it.
+type recordingPublisher struct {
+	published []amqp.Publishing
+}
+
+func (p *recordingPublisher) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func TestHandleLogWithoutReplyTo(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockInsertUseCase := usecase.NewMockIInsertLogUseCase(ctrl)
+	mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), gomock.Any()).Return("log-id", nil).Times(1)
+	publisher := &recordingPublisher{}
+	handler := NewAMQPLogHandler(mockInsertUseCase, publisher, RequestValidator{}, NewMockIDeadLetterPublisher(ctrl))
+
+	_, msg := testMsg(t, time.Now())
+	acknowledger := &fakeAcknowledger{}
+	msg.Acknowledger = acknowledger
+	handler.HandleLog(msg)
+
+	if len(publisher.published) != 0 {
+		t.Errorf("expected no response without a reply queue, got %d", len(publisher.published))
+	}
+	if !acknowledger.acked {
+		t.Errorf("Expected the message to be acknowledged")
+	}
+}
+
 func TestParseAMQPLog(t *testing.T) {
 	t.Parallel()
 	t.Run("success", func(t *testing.T) {
@@ -256,6 +355,7 @@ func testMsg(t *testing.T, fixedTime time.Time) (AMQPLogRequest, amqp.Delivery)
 		DestinationService: "AuthService",
 		RequestType:        "POST",
 		Content:            "User created successfully.",
+		Attributes:         map[string]any{"status": float64(201)},
 	}
 
 	var payload bytes.Buffer
@@ -288,10 +388,24 @@ func testCTRMsg(t *testing.T, fixedTime time.Time) (AMQPCTRLogRequest, amqp.Deli
 func SetupLogListTest(t *testing.T) (*gomock.Controller, *usecase.MockIListLogsUseCase, *HttpLogHandler) {
 	ctrl := gomock.NewController(t)
 	mockListUseCase := usecase.NewMockIListLogsUseCase(ctrl)
-	handler := NewHttpLogHandler(mockListUseCase)
+	handler := NewHttpLogHandler(mockListUseCase, usecase.NewMockIGetLogUseCase(ctrl), usecase.NewMockIInsertLogUseCase(ctrl), usecase.NewMockIStreamLogsUseCase(ctrl), RequestValidator{})
 	return ctrl, mockListUseCase, handler
 }
 
+func SetupLogGetTest(t *testing.T) (*gomock.Controller, *usecase.MockIGetLogUseCase, *HttpLogHandler) {
+	ctrl := gomock.NewController(t)
+	mockGetUseCase := usecase.NewMockIGetLogUseCase(ctrl)
+	handler := NewHttpLogHandler(usecase.NewMockIListLogsUseCase(ctrl), mockGetUseCase, usecase.NewMockIInsertLogUseCase(ctrl), usecase.NewMockIStreamLogsUseCase(ctrl), RequestValidator{})
+	return ctrl, mockGetUseCase, handler
+}
+
+func SetupLogCreateTest(t *testing.T) (*gomock.Controller, *usecase.MockIInsertLogUseCase, *HttpLogHandler) {
+	ctrl := gomock.NewController(t)
+	mockInsertUseCase := usecase.NewMockIInsertLogUseCase(ctrl)
+	handler := NewHttpLogHandler(usecase.NewMockIListLogsUseCase(ctrl), usecase.NewMockIGetLogUseCase(ctrl), mockInsertUseCase, usecase.NewMockIStreamLogsUseCase(ctrl), RequestValidator{})
+	return ctrl, mockInsertUseCase, handler
+}
+
 func TestHandleLogList(t *testing.T) {
 	t.Parallel()
 	t.Run("Success", func(t *testing.T) {
@@ -301,6 +415,7 @@ func TestHandleLogList(t *testing.T) {
 		now := time.Now()
 		expectedLogs := []*usecase.ListLogDto{
 			{
+				ID:                 "log-id-1",
 				LogLevel:           "INFO",
 				Date:               now,
 				DestinationService: "ServiceA",
@@ -309,6 +424,7 @@ func TestHandleLogList(t *testing.T) {
 				Content:            "First log message",
 			},
 			{
+				ID:                 "log-id-2",
 				LogLevel:           "ERROR",
 				Date:               now,
 				DestinationService: "ServiceC",
@@ -318,7 +434,7 @@ func TestHandleLogList(t *testing.T) {
 			},
 		}
 
-		mockListUseCase.EXPECT().ListLogs(gomock.Any()).Return(expectedLogs, nil).Times(1)
+		mockListUseCase.EXPECT().ListLogs(gomock.Any(), &usecase.ListLogsQueryDto{}).Return(expectedLogs, "", nil).Times(1)
 
 		req, err := http.NewRequest("GET", "/logs", nil)
 		if err != nil {
@@ -334,6 +450,7 @@ func TestHandleLogList(t *testing.T) {
 
 		expectedResponse := []HttpLogListResponse{
 			{
+				ID:                 "log-id-1",
 				LogLevel:           "INFO",
 				Date:               now,
 				DestinationService: "ServiceA",
@@ -342,6 +459,7 @@ func TestHandleLogList(t *testing.T) {
 				Content:            "First log message",
 			},
 			{
+				ID:                 "log-id-2",
 				LogLevel:           "ERROR",
 				Date:               now,
 				DestinationService: "ServiceC",
@@ -361,11 +479,87 @@ func TestHandleLogList(t *testing.T) {
 		}
 	})
 
+	t.Run("Query Parameters", func(t *testing.T) {
+		t.Parallel()
+		_, mockListUseCase, handler := SetupLogListTest(t)
+
+		from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 9, 24, 0, 0, 0, 0, time.UTC)
+		mockListUseCase.EXPECT().ListLogs(gomock.Any(), &usecase.ListLogsQueryDto{
+			LogLevel:           "ERROR",
+			SourceService:      "ServiceB",
+			DestinationService: "ServiceA",
+			RequestType:        "GET",
+			From:               from,
+			To:                 to,
+			Content:            "timeout",
+			Attributes:         map[string]any{"status": float64(504), "user_id": "u-1", "code": "42"},
+			Order:              "asc",
+			PageSize:           10,
+			Cursor:             "cursor",
+		}).Return([]*usecase.ListLogDto{}, "next-cursor", nil).Times(1)
+
+		req, err := http.NewRequest("GET", "/logs?level=ERROR&source_service=ServiceB&destination_service=ServiceA"+
+			"&request_type=GET&from=2024-09-23T00:00:00Z&to=2024-09-24T00:00:00Z&content=timeout"+
+			"&order=asc&page_size=10&cursor=cursor&attr.status=504&attr.user_id=u-1&attr.code=%2242%22", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogList(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+		if got := rr.Header().Get(NextCursorHeader); got != "next-cursor" {
+			t.Errorf("handler returned wrong next cursor: got %v want %v", got, "next-cursor")
+		}
+	})
+
+	t.Run("Invalid Query Parameters", func(t *testing.T) {
+		t.Parallel()
+		for _, query := range []string{"from=yesterday", "to=2024-09-24", "page_size=0", "page_size=ten", "attr.=1", "attr.a=1&attr.a=2"} {
+			_, _, handler := SetupLogListTest(t)
+
+			req, err := http.NewRequest("GET", "/logs?"+query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.HandleLogList(rr, req)
+
+			if status := rr.Code; status != http.StatusBadRequest {
+				t.Errorf("%s: handler returned wrong status code: got %v want %v", query, status, http.StatusBadRequest)
+			}
+		}
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		t.Parallel()
+		_, mockListUseCase, handler := SetupLogListTest(t)
+
+		mockListUseCase.EXPECT().ListLogs(gomock.Any(), gomock.Any()).Return(nil, "", usecase.ErrInvalidCursor).Times(1)
+
+		req, err := http.NewRequest("GET", "/logs?cursor=broken", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogList(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+	})
+
 	t.Run("ListLogs Failure", func(t *testing.T) {
 		t.Parallel()
 		_, mockListUseCase, handler := SetupLogListTest(t)
 
-		mockListUseCase.EXPECT().ListLogs(gomock.Any()).Return(nil, errors.New("failed to list logs")).Times(1)
+		mockListUseCase.EXPECT().ListLogs(gomock.Any(), gomock.Any()).Return(nil, "", errors.New("failed to list logs")).Times(1)
 
 		req, err := http.NewRequest("GET", "/logs", nil)
 		if err != nil {
@@ -400,7 +594,7 @@ func TestHandleLogList(t *testing.T) {
 				Content:            "First log message",
 			},
 		}
-		mockListUseCase.EXPECT().ListLogs(gomock.Any()).Return(logs, nil).Times(1)
+		mockListUseCase.EXPECT().ListLogs(gomock.Any(), gomock.Any()).Return(logs, "", nil).Times(1)
 
 		errorWriter := &errorWriterResponse{}
 
@@ -425,3 +619,239 @@ func TestHandleLogList(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleLogGet(t *testing.T) {
+	t.Parallel()
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		_, mockGetUseCase, handler := SetupLogGetTest(t)
+
+		now := time.Now()
+		mockGetUseCase.EXPECT().GetLog(gomock.Any(), "log-id").Return(&usecase.GetLogDto{
+			ID:                 "log-id",
+			LogLevel:           "INFO",
+			Date:               now,
+			DestinationService: "ServiceA",
+			SourceService:      "ServiceB",
+			RequestType:        "GET",
+			Content:            "First log message",
+		}, nil).Times(1)
+
+		req, err := http.NewRequest("GET", "/logs/log-id", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetPathValue("id", "log-id")
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogGet(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+
+		expectedResponse := HttpLogGetResponse{
+			ID:                 "log-id",
+			LogLevel:           "INFO",
+			Date:               now,
+			DestinationService: "ServiceA",
+			SourceService:      "ServiceB",
+			RequestType:        "GET",
+			Content:            "First log message",
+		}
+
+		var got HttpLogGetResponse
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response body: %v", err)
+		}
+
+		if diff := cmp.Diff(expectedResponse, got); diff != "" {
+			t.Errorf("handler returned unexpected JSON (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		t.Parallel()
+		_, mockGetUseCase, handler := SetupLogGetTest(t)
+
+		mockGetUseCase.EXPECT().GetLog(gomock.Any(), "missing").Return(nil, domain.ErrLogNotFound).Times(1)
+
+		req, err := http.NewRequest("GET", "/logs/missing", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetPathValue("id", "missing")
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogGet(rr, req)
+
+		if status := rr.Code; status != http.StatusNotFound {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+		}
+	})
+
+	t.Run("GetLog Failure", func(t *testing.T) {
+		t.Parallel()
+		_, mockGetUseCase, handler := SetupLogGetTest(t)
+
+		mockGetUseCase.EXPECT().GetLog(gomock.Any(), "log-id").Return(nil, errors.New("failed to get log")).Times(1)
+
+		req, err := http.NewRequest("GET", "/logs/log-id", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetPathValue("id", "log-id")
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogGet(rr, req)
+
+		if status := rr.Code; status != http.StatusInternalServerError {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusInternalServerError)
+		}
+
+		expectedError := "Internal Server Error: failed to get log\n"
+		if rr.Body.String() != expectedError {
+			t.Errorf("handler returned unexpected error message: got %v want %v", rr.Body.String(), expectedError)
+		}
+	})
+}
+
+func TestHandleLogCreate(t *testing.T) {
+	t.Parallel()
+	fixedTime := time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+	logRequest, msg := testMsg(t, fixedTime)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), newInsertLogDto(logRequest)).Return("log-id", nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/logs", bytes.NewReader(msg.Body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusCreated {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+		}
+		var got HttpLogInsertResponse
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response body: %v", err)
+		}
+		want := HttpLogInsertResponse{StatusCode: utils.OK, Message: "OK", ID: "log-id"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("handler returned unexpected JSON (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		t.Parallel()
+		_, _, handler := SetupLogCreateTest(t)
+
+		req := httptest.NewRequest("POST", "/logs", strings.NewReader("{"))
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+		var got HttpLogInsertResponse
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response body: %v", err)
+		}
+		if got.StatusCode != utils.INVALID_ARGUMENT {
+			t.Errorf("handler returned wrong status: got %v want %v", got.StatusCode, utils.INVALID_ARGUMENT)
+		}
+	})
+
+	t.Run("Body Too Large", func(t *testing.T) {
+		t.Parallel()
+		padding := strings.Repeat("x", maxHttpLogBodySize)
+		for contentType, body := range map[string]string{
+			"application/json":     `{"content":"` + padding + `"}`,
+			"application/x-ndjson": `{"content":"first"}` + "\n" + `{"content":"` + padding + `"}` + "\n",
+		} {
+			_, _, handler := SetupLogCreateTest(t)
+
+			req := httptest.NewRequest("POST", "/logs", strings.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			rr := httptest.NewRecorder()
+
+			handler.HandleLogCreate(rr, req)
+
+			if status := rr.Code; status != http.StatusRequestEntityTooLarge {
+				t.Errorf("%s: handler returned wrong status code: got %v want %v", contentType, status, http.StatusRequestEntityTooLarge)
+			}
+		}
+	})
+
+	t.Run("InsertLog Failure", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), gomock.Any()).Return("", errors.New("failed to insert log")).Times(1)
+
+		req := httptest.NewRequest("POST", "/logs", bytes.NewReader(msg.Body))
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusInternalServerError {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, dto *usecase.InsertLogDto) (string, error) {
+				if dto.Content == "fail" {
+					return "", errors.New("failed to insert log")
+				}
+				return "id-" + dto.Content, nil
+			}).Times(2)
+
+		body := `{"content":"first","source_service":"s","date":"2024-09-23T23:07:32Z"}` + "\n" +
+			"\n" +
+			"not json\n" +
+			`{"content":"fail","source_service":"s","date":"2024-09-23T23:07:32Z"}` + "\n" +
+			`{"content":"unknown","source_service":"s","date":"2024-09-23T23:07:32Z","level":"INFO"}` + "\n"
+		req := httptest.NewRequest("POST", "/logs", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+		if got := rr.Header().Get("Content-Type"); got != "application/x-ndjson" {
+			t.Errorf("handler returned wrong content type: got %v", got)
+		}
+
+		var got []HttpLogBulkInsertResponse
+		decoder := json.NewDecoder(rr.Body)
+		for decoder.More() {
+			var result HttpLogBulkInsertResponse
+			if err := decoder.Decode(&result); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+			result.Message = ""
+			got = append(got, result)
+		}
+		want := []HttpLogBulkInsertResponse{
+			{Line: 1, StatusCode: utils.OK, ID: "id-first"},
+			{Line: 3, StatusCode: utils.INVALID_ARGUMENT},
+			{Line: 4, StatusCode: utils.INTERNAL},
+			{Line: 5, StatusCode: utils.INVALID_ARGUMENT, Errors: []FieldError{{Field: "level", Message: "is unknown"}}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("handler returned unexpected results (-want +got):\n%s", diff)
+		}
+	})
+}
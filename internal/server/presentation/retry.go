@@ -0,0 +1,87 @@
+package presentation
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	DefaultMaxRetries     = 5
+	DefaultRetryBaseDelay = time.Second
+	DefaultRetryMaxDelay  = 5 * time.Minute
+)
+
+// RetryPolicy bounds the redeliveries of a CTR event that could not be stored.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after which the event is dead-lettered.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry. It doubles on every retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two retries.
+	MaxDelay time.Duration
+}
+
+// NewRetryPolicyFromEnv reads the policy from CTR_MAX_RETRIES,
+// CTR_RETRY_BASE_DELAY and CTR_RETRY_MAX_DELAY, using the defaults for the
+// variables that are unset or invalid.
+func NewRetryPolicyFromEnv() RetryPolicy {
+	p := RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+	if v := os.Getenv("CTR_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			p.MaxRetries = n
+		} else {
+			log.Printf("Ignoring invalid CTR_MAX_RETRIES %q", v)
+		}
+	}
+	if v := os.Getenv("CTR_RETRY_BASE_DELAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			p.BaseDelay = d
+		} else {
+			log.Printf("Ignoring invalid CTR_RETRY_BASE_DELAY %q", v)
+		}
+	}
+	if v := os.Getenv("CTR_RETRY_MAX_DELAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			p.MaxDelay = d
+		} else {
+			log.Printf("Ignoring invalid CTR_RETRY_MAX_DELAY %q", v)
+		}
+	}
+	return p
+}
+
+// Backoff returns the delay before the retry following the given number of
+// previous retries.
+func (p RetryPolicy) Backoff(retries int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < retries && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxDelay)
+}
+
+// IDeadLetterPublisher moves deliveries that could not be handled to the
+// dead-letter queue of their queue.
+type IDeadLetterPublisher interface {
+	// PublishDeadLetter publishes msg to the dead-letter queue along with the
+	// reason why it was given up on.
+	PublishDeadLetter(ctx context.Context, msg amqp.Delivery, reason string) error
+}
+
+// IRetryPublisher moves deliveries that could not be handled out of their queue.
+type IRetryPublisher interface {
+	IDeadLetterPublisher
+	// Retries returns how many times msg has already been retried.
+	Retries(msg amqp.Delivery) int
+	// PublishRetry publishes msg again so that it is redelivered after delay.
+	PublishRetry(ctx context.Context, msg amqp.Delivery, delay time.Duration) error
+}
@@ -0,0 +1,149 @@
+package presentation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/usecase"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+	p := RetryPolicy{MaxRetries: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	for retries, want := range []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second,
+	} {
+		if got := p.Backoff(retries); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", retries, got, want)
+		}
+	}
+	if got := p.Backoff(1000); got != p.MaxDelay {
+		t.Errorf("Backoff(1000) = %v, want %v", got, p.MaxDelay)
+	}
+}
+
+func TestNewRetryPolicyFromEnv(t *testing.T) {
+	t.Setenv("CTR_MAX_RETRIES", "3")
+	t.Setenv("CTR_RETRY_BASE_DELAY", "-1s")
+	t.Setenv("CTR_RETRY_MAX_DELAY", "1m")
+
+	want := RetryPolicy{MaxRetries: 3, BaseDelay: DefaultRetryBaseDelay, MaxDelay: time.Minute}
+	if got := NewRetryPolicyFromEnv(); got != want {
+		t.Errorf("NewRetryPolicyFromEnv() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleCTRLog(t *testing.T) {
+	t.Parallel()
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	validBody := `{"eventType":"click","objectId":"banner","createdAt":"2024-09-23T23:07:32Z"}`
+	errInsert := errors.New("database is down")
+
+	tests := []struct {
+		name        string
+		body        string
+		mockFunc    func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher)
+		wantAcked   bool
+		wantNacked  bool
+		wantRequeue bool
+	}{
+		{
+			name: "inserted",
+			body: validBody,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				uc.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantAcked: true,
+		},
+		{
+			name: "missing createdAt is stamped with the reception time",
+			body: `{"eventType":"click","objectId":"banner"}`,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				uc.EXPECT().InsertCTRLog(gomock.Any(), gomock.Cond(func(x any) bool {
+					return !x.(*usecase.InsertCTRLogDto).CreatedAt.IsZero()
+				})).Return(nil)
+			},
+			wantAcked: true,
+		},
+		{
+			name: "invalid request is dead-lettered",
+			body: `{"eventType":"hover"}`,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				r.EXPECT().PublishDeadLetter(gomock.Any(), gomock.Any(), gomock.Cond(func(reason any) bool {
+					return strings.HasPrefix(reason.(string), "invalid CTR log request")
+				})).Return(nil)
+			},
+			wantAcked: true,
+		},
+		{
+			name: "invalid request is dropped when dead-lettering fails",
+			body: `not json`,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				r.EXPECT().PublishDeadLetter(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("channel closed"))
+			},
+			wantNacked: true,
+		},
+		{
+			name: "insert failure is retried with backoff",
+			body: validBody,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				uc.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).Return(errInsert)
+				r.EXPECT().Retries(gomock.Any()).Return(2)
+				r.EXPECT().PublishRetry(gomock.Any(), gomock.Any(), 4*time.Second).Return(nil)
+			},
+			wantAcked: true,
+		},
+		{
+			name: "insert failure is requeued when the retry cannot be published",
+			body: validBody,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				uc.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).Return(errInsert)
+				r.EXPECT().Retries(gomock.Any()).Return(0)
+				r.EXPECT().PublishRetry(gomock.Any(), gomock.Any(), time.Second).Return(errors.New("channel closed"))
+			},
+			wantNacked:  true,
+			wantRequeue: true,
+		},
+		{
+			name: "exhausted retries are dead-lettered",
+			body: validBody,
+			mockFunc: func(uc *usecase.MockIInsertCTRLogUseCase, r *MockIRetryPublisher) {
+				uc.EXPECT().InsertCTRLog(gomock.Any(), gomock.Any()).Return(errInsert)
+				r.EXPECT().Retries(gomock.Any()).Return(3)
+				r.EXPECT().PublishDeadLetter(gomock.Any(), gomock.Any(),
+					"failed to insert CTR log after 3 retries: database is down").Return(nil)
+			},
+			wantAcked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockUseCase := usecase.NewMockIInsertCTRLogUseCase(ctrl)
+			mockRetrier := NewMockIRetryPublisher(ctrl)
+			tt.mockFunc(mockUseCase, mockRetrier)
+			handler := NewAMQPCTRLogHandler(mockUseCase, nil, RequestValidator{}, mockRetrier, policy)
+
+			acknowledger := &fakeAcknowledger{}
+			handler.HandleCTRLog(amqp.Delivery{Acknowledger: acknowledger, Body: []byte(tt.body)})
+
+			if acknowledger.acked != tt.wantAcked {
+				t.Errorf("acked = %v, want %v", acknowledger.acked, tt.wantAcked)
+			}
+			if acknowledger.nacked != tt.wantNacked {
+				t.Errorf("nacked = %v, want %v", acknowledger.nacked, tt.wantNacked)
+			}
+			if acknowledger.requeue != tt.wantRequeue {
+				t.Errorf("requeue = %v, want %v", acknowledger.requeue, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"log_service/internal/server/usecase"
+)
+
+// Output formats of GET /services/graph.
+const (
+	ServiceGraphFormatJSON    = "json"
+	ServiceGraphFormatDOT     = "dot"
+	ServiceGraphFormatMermaid = "mermaid"
+)
+
+// HttpServiceGraphHandler reports the dependency graph of the services.
+type HttpServiceGraphHandler struct {
+	UseCase usecase.IServiceGraphUseCase
+}
+
+// NewHttpServiceGraphHandler creates a new instance of HttpServiceGraphHandler with the given use case.
+func NewHttpServiceGraphHandler(useCase usecase.IServiceGraphUseCase) *HttpServiceGraphHandler {
+	return &HttpServiceGraphHandler{
+		UseCase: useCase,
+	}
+}
+
+// HandleServiceGraph returns the services which exchanged logs within the
+// requested time range and the calls between them.
+//
+// The graph is returned as JSON by default. With format=dot or
+// format=mermaid, it is returned as a Graphviz or Mermaid diagram which can
+// be pasted into documentation.
+func (h *HttpServiceGraphHandler) HandleServiceGraph(w http.ResponseWriter, r *http.Request) {
+	query, format, err := ParseHttpServiceGraphQuery(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	graph, err := h.UseCase.GetServiceGraph(r.Context(), query)
+	if errors.Is(err, usecase.ErrInvalidTimeRange) {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get service graph: %v", err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case ServiceGraphFormatDOT:
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		writeServiceGraphDOT(w, graph)
+	case ServiceGraphFormatMermaid:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		writeServiceGraphMermaid(w, graph)
+	default:
+		writeJSON(w, http.StatusOK, newHttpServiceGraphResponse(graph))
+	}
+}
+
+// ParseHttpServiceGraphQuery reads the time range and the output format of
+// GET /services/graph from the query string. Dates in "from" and "to" are
+// formatted as RFC 3339.
+func ParseHttpServiceGraphQuery(r *http.Request) (*usecase.ServiceGraphQueryDto, string, error) {
+	values := r.URL.Query()
+	query := &usecase.ServiceGraphQueryDto{}
+
+	format := values.Get("format")
+	switch format {
+	case "":
+		format = ServiceGraphFormatJSON
+	case ServiceGraphFormatJSON, ServiceGraphFormatDOT, ServiceGraphFormatMermaid:
+	default:
+		return nil, "", fmt.Errorf("invalid format: %q", format)
+	}
+
+	var err error
+	if from := values.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, "", fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, "", fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	return query, format, nil
+}
+
+func newHttpServiceGraphResponse(graph *usecase.ServiceGraphDto) HttpServiceGraphResponse {
+	response := HttpServiceGraphResponse{
+		Nodes: make([]HttpServiceNodeResponse, len(graph.Nodes)),
+		Edges: make([]HttpServiceEdgeResponse, len(graph.Edges)),
+	}
+	for i, node := range graph.Nodes {
+		response.Nodes[i] = HttpServiceNodeResponse{
+			Name:      node.Name,
+			CallsIn:   node.CallsIn,
+			CallsOut:  node.CallsOut,
+			ErrorsIn:  node.ErrorsIn,
+			ErrorsOut: node.ErrorsOut,
+		}
+	}
+	for i, edge := range graph.Edges {
+		response.Edges[i] = HttpServiceEdgeResponse{
+			Source:      edge.Source,
+			Destination: edge.Destination,
+			Calls:       edge.Calls,
+			Errors:      edge.Errors,
+			Levels:      edge.Levels,
+		}
+	}
+	return response
+}
+
+// serviceEdgeLabel describes the calls of an edge, such as "10 calls, 2 errors".
+func serviceEdgeLabel(edge *usecase.ServiceEdgeDto) string {
+	label := plural(edge.Calls, "call")
+	if edge.Errors > 0 {
+		label += ", " + plural(edge.Errors, "error")
+	}
+	return label
+}
+
+func plural(n int64, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// writeServiceGraphDOT writes the graph in the Graphviz DOT language.
+// Edges with errors are drawn in red.
+func writeServiceGraphDOT(w io.Writer, graph *usecase.ServiceGraphDto) {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	fmt.Fprintln(w, "digraph services {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(w, "  \"%s\";\n", quote.Replace(node.Name))
+	}
+	for _, edge := range graph.Edges {
+		attrs := fmt.Sprintf("label=\"%s\"", serviceEdgeLabel(edge))
+		if edge.Errors > 0 {
+			attrs += ", color=red"
+		}
+		fmt.Fprintf(w, "  \"%s\" -> \"%s\" [%s];\n", quote.Replace(edge.Source), quote.Replace(edge.Destination), attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeServiceGraphMermaid writes the graph as a Mermaid flowchart. Service
+// names are used as labels only, since they may contain characters which are
+// not allowed in Mermaid node IDs.
+func writeServiceGraphMermaid(w io.Writer, graph *usecase.ServiceGraphDto) {
+	quote := strings.NewReplacer(`"`, "#quot;", "\n", " ")
+
+	ids := make(map[string]string, len(graph.Nodes))
+	fmt.Fprintln(w, "graph LR")
+	for i, node := range graph.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[node.Name], quote.Replace(node.Name))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(w, "  %s -->|\"%s\"| %s\n", ids[edge.Source], serviceEdgeLabel(edge), ids[edge.Destination])
+	}
+}
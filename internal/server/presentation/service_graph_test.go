@@ -0,0 +1,149 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/usecase"
+)
+
+func testServiceGraph() *usecase.ServiceGraphDto {
+	return &usecase.ServiceGraphDto{
+		Nodes: []*usecase.ServiceNodeDto{
+			{Name: "auth", CallsOut: 1},
+			{Name: `user "v2"`, CallsIn: 3, ErrorsIn: 2},
+		},
+		Edges: []*usecase.ServiceEdgeDto{
+			{Source: "auth", Destination: `user "v2"`, Calls: 3, Errors: 2, Levels: map[string]int64{"INFO": 1, "ERROR": 2}},
+		},
+	}
+}
+
+func TestHandleServiceGraph(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	testCases := map[string]struct {
+		url             string
+		mockFunc        func(*usecase.MockIServiceGraphUseCase)
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		"DOT": {
+			url: "/services/graph?format=dot&from=2024-09-23T00:00:00Z&to=2024-09-23T01:00:00Z",
+			mockFunc: func(m *usecase.MockIServiceGraphUseCase) {
+				m.EXPECT().GetServiceGraph(gomock.Any(), &usecase.ServiceGraphQueryDto{From: from, To: to}).Return(testServiceGraph(), nil).Times(1)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/vnd.graphviz; charset=utf-8",
+			wantBody: "digraph services {\n" +
+				"  rankdir=LR;\n" +
+				"  \"auth\";\n" +
+				"  \"user \\\"v2\\\"\";\n" +
+				"  \"auth\" -> \"user \\\"v2\\\"\" [label=\"3 calls, 2 errors\", color=red];\n" +
+				"}\n",
+		},
+		"Mermaid": {
+			url: "/services/graph?format=mermaid",
+			mockFunc: func(m *usecase.MockIServiceGraphUseCase) {
+				m.EXPECT().GetServiceGraph(gomock.Any(), &usecase.ServiceGraphQueryDto{}).Return(testServiceGraph(), nil).Times(1)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody: "graph LR\n" +
+				"  n0[\"auth\"]\n" +
+				"  n1[\"user #quot;v2#quot;\"]\n" +
+				"  n0 -->|\"3 calls, 2 errors\"| n1\n",
+		},
+		"Invalid Format": {
+			url:        "/services/graph?format=svg",
+			mockFunc:   func(m *usecase.MockIServiceGraphUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Invalid From": {
+			url:        "/services/graph?from=yesterday",
+			mockFunc:   func(m *usecase.MockIServiceGraphUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Invalid Time Range": {
+			url: "/services/graph",
+			mockFunc: func(m *usecase.MockIServiceGraphUseCase) {
+				m.EXPECT().GetServiceGraph(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidTimeRange).Times(1)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Internal Error": {
+			url: "/services/graph",
+			mockFunc: func(m *usecase.MockIServiceGraphUseCase) {
+				m.EXPECT().GetServiceGraph(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error")).Times(1)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockUseCase := usecase.NewMockIServiceGraphUseCase(ctrl)
+			tc.mockFunc(mockUseCase)
+			handler := NewHttpServiceGraphHandler(mockUseCase)
+
+			rr := httptest.NewRecorder()
+			handler.HandleServiceGraph(rr, httptest.NewRequest("GET", tc.url, nil))
+
+			if status := rr.Code; status != tc.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rr.Header().Get("Content-Type"); got != tc.wantContentType {
+				t.Errorf("handler returned wrong content type: got %v want %v", got, tc.wantContentType)
+			}
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("handler returned unexpected body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleServiceGraphJSON(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockUseCase := usecase.NewMockIServiceGraphUseCase(ctrl)
+	mockUseCase.EXPECT().GetServiceGraph(gomock.Any(), gomock.Any()).Return(testServiceGraph(), nil).Times(1)
+	handler := NewHttpServiceGraphHandler(mockUseCase)
+
+	rr := httptest.NewRecorder()
+	handler.HandleServiceGraph(rr, httptest.NewRequest("GET", "/services/graph", nil))
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	var got HttpServiceGraphResponse
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	want := HttpServiceGraphResponse{
+		Nodes: []HttpServiceNodeResponse{
+			{Name: "auth", CallsOut: 1},
+			{Name: `user "v2"`, CallsIn: 3, ErrorsIn: 2},
+		},
+		Edges: []HttpServiceEdgeResponse{
+			{Source: "auth", Destination: `user "v2"`, Calls: 3, Errors: 2, Levels: map[string]int64{"INFO": 1, "ERROR": 2}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("handler returned unexpected JSON (-want +got):\n%s", diff)
+	}
+}
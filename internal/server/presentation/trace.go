@@ -0,0 +1,116 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+)
+
+// TraceparentHeader is the W3C Trace Context header, read from both HTTP
+// requests and AMQP message headers.
+const TraceparentHeader = "traceparent"
+
+type HttpTraceHandler struct {
+	GetUseCase usecase.IGetTraceUseCase
+}
+
+func NewHttpTraceHandler(getUseCase usecase.IGetTraceUseCase) *HttpTraceHandler {
+	return &HttpTraceHandler{
+		GetUseCase: getUseCase,
+	}
+}
+
+// HandleTraceGet returns the logs of the trace in the path, oldest first.
+func (h *HttpTraceHandler) HandleTraceGet(w http.ResponseWriter, r *http.Request) {
+	logs, err := h.GetUseCase.GetTrace(r.Context(), r.PathValue("trace_id"))
+	if errors.Is(err, domain.ErrInvalidTraceID) {
+		http.Error(w, fmt.Sprintf("Bad Request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrTraceNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get trace: %v", err)
+		http.Error(w, fmt.Sprintf("Internal Server Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	responseLogs := make([]HttpLogListResponse, len(logs))
+	for i, eachLog := range logs {
+		responseLogs[i] = newHttpLogListResponse(eachLog)
+	}
+	writeJSON(w, http.StatusOK, responseLogs)
+}
+
+// ParseTraceparent extracts the trace ID and the parent span ID from a W3C
+// traceparent header such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+//
+// ok is false if the header is malformed. As required by the specification,
+// headers of a future version are accepted as long as they start with the
+// fields of version 00.
+func ParseTraceparent(header string) (traceID, parentID string, ok bool) {
+	header = strings.TrimSpace(header)
+	if len(header) < 55 || (len(header) > 55 && header[55] != '-') {
+		return "", "", false
+	}
+	version, rest, _ := strings.Cut(header[:55], "-")
+	if !isLowerHex(version) || len(version) != 2 || version == "ff" {
+		return "", "", false
+	}
+	if version == "00" && len(header) != 55 {
+		return "", "", false
+	}
+	parts := strings.Split(rest, "-")
+	if len(parts) != 3 || !isLowerHex(parts[2]) || len(parts[2]) != 2 {
+		return "", "", false
+	}
+	if domain.ValidateTraceID(parts[0]) != nil || domain.ValidateSpanID(parts[1]) != nil {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// isLowerHex reports whether s is made of lowercase hex digits only.
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// applyTraceparent fills the trace context of req from a traceparent header.
+// The log belongs to the span of the caller, so the parent ID of the header
+// becomes the span ID of the log.
+//
+// A trace context in the body takes precedence, and malformed headers are
+// ignored.
+func applyTraceparent(req *AMQPLogRequest, header string) {
+	if req.TraceID != "" || req.SpanID != "" || req.ParentSpanID != "" {
+		return
+	}
+	traceID, parentID, ok := ParseTraceparent(header)
+	if !ok {
+		return
+	}
+	req.TraceID = traceID
+	req.SpanID = parentID
+}
+
+// amqpTraceparent returns the traceparent header of msg, if any.
+func amqpTraceparent(msg amqp.Delivery) string {
+	header, _ := msg.Headers[TraceparentHeader].(string)
+	return header
+}
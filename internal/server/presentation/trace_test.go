@@ -0,0 +1,264 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+	"log_service/internal/server/usecase"
+	"log_service/internal/utils"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+var (
+	testTraceparent = fmt.Sprintf("00-%s-%s-01", testTraceID, testSpanID)
+	testDate        = time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+)
+
+// testLogBody returns a valid log request with the given content, followed
+// by the extra JSON members.
+func testLogBody(content, extra string) string {
+	return fmt.Sprintf(`{"content":%q,"source_service":"s","date":%q%s}`, content, testDate.Format(time.RFC3339), extra)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		header string
+		wantOK bool
+	}{
+		"valid":                  {header: testTraceparent, wantOK: true},
+		"surrounding spaces":     {header: " " + testTraceparent + " ", wantOK: true},
+		"future version":         {header: "01-" + testTraceID + "-" + testSpanID + "-01-extra", wantOK: true},
+		"empty":                  {header: ""},
+		"version ff":             {header: "ff-" + testTraceID + "-" + testSpanID + "-01"},
+		"version 00 with suffix": {header: testTraceparent + "-extra"},
+		"uppercase trace ID":     {header: "00-" + strings.ToUpper(testTraceID) + "-" + testSpanID + "-01"},
+		"zero trace ID":          {header: "00-" + strings.Repeat("0", 32) + "-" + testSpanID + "-01"},
+		"zero parent ID":         {header: "00-" + testTraceID + "-" + strings.Repeat("0", 16) + "-01"},
+		"bad flags":              {header: "00-" + testTraceID + "-" + testSpanID + "-0g"},
+		"wrong delimiter":        {header: "00_" + testTraceID + "_" + testSpanID + "_01"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			traceID, parentID, ok := ParseTraceparent(tc.header)
+			if ok != tc.wantOK {
+				t.Fatalf("ParseTraceparent(%q) ok = %v, want %v", tc.header, ok, tc.wantOK)
+			}
+			if ok && (traceID != testTraceID || parentID != testSpanID) {
+				t.Errorf("ParseTraceparent(%q) = %q, %q", tc.header, traceID, parentID)
+			}
+		})
+	}
+}
+
+func TestParseAMQPLogTraceparent(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		body        string
+		traceparent any
+		want        AMQPLogRequest
+	}{
+		"header fills trace context": {
+			body:        `{"content":"c"}`,
+			traceparent: testTraceparent,
+			want:        AMQPLogRequest{Content: "c", TraceID: testTraceID, SpanID: testSpanID},
+		},
+		"body takes precedence": {
+			body:        `{"content":"c","trace_id":"0af7651916cd43dd8448eb211c80319c","span_id":"b7ad6b7169203331"}`,
+			traceparent: testTraceparent,
+			want:        AMQPLogRequest{Content: "c", TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"},
+		},
+		"invalid header is ignored": {
+			body:        `{"content":"c"}`,
+			traceparent: "00-invalid",
+			want:        AMQPLogRequest{Content: "c"},
+		},
+		"non-string header is ignored": {
+			body:        `{"content":"c"}`,
+			traceparent: int32(1),
+			want:        AMQPLogRequest{Content: "c"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			msg := amqp.Delivery{
+				Body:    []byte(tc.body),
+				Headers: amqp.Table{TraceparentHeader: tc.traceparent},
+			}
+			got, err := ParseAMQPLog(msg)
+			if err != nil {
+				t.Fatalf("ParseAMQPLog() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseAMQPLog() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleLogCreateTraceparent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Header", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), &usecase.InsertLogDto{
+			Date:          testDate,
+			SourceService: "s",
+			Content:       "c",
+			TraceID:       testTraceID,
+			SpanID:        testSpanID,
+		}).Return("log-id", nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/logs", strings.NewReader(testLogBody("c", "")))
+		req.Header.Set(TraceparentHeader, testTraceparent)
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusCreated {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+		}
+	})
+
+	t.Run("NDJSON Header", func(t *testing.T) {
+		t.Parallel()
+		_, mockInsertUseCase, handler := SetupLogCreateTest(t)
+
+		mockInsertUseCase.EXPECT().InsertLog(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, dto *usecase.InsertLogDto) (string, error) {
+				if dto.TraceID != testTraceID || dto.SpanID != testSpanID {
+					t.Errorf("InsertLog() got trace context %q/%q", dto.TraceID, dto.SpanID)
+				}
+				return "log-id", nil
+			}).Times(2)
+
+		req := httptest.NewRequest("POST", "/logs", strings.NewReader(testLogBody("a", "")+"\n"+testLogBody("b", "")+"\n"))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		req.Header.Set(TraceparentHeader, testTraceparent)
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+	})
+
+	t.Run("Invalid Trace ID", func(t *testing.T) {
+		t.Parallel()
+		_, _, handler := SetupLogCreateTest(t)
+
+		req := httptest.NewRequest("POST", "/logs", strings.NewReader(testLogBody("c", `,"trace_id":"xyz"`)))
+		rr := httptest.NewRecorder()
+
+		handler.HandleLogCreate(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+		}
+		var got HttpLogInsertResponse
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response body: %v", err)
+		}
+		if got.StatusCode != utils.INVALID_ARGUMENT {
+			t.Errorf("handler returned wrong status: got %v want %v", got.StatusCode, utils.INVALID_ARGUMENT)
+		}
+		if len(got.Errors) != 1 || got.Errors[0].Field != "trace_id" {
+			t.Errorf("handler returned unexpected field errors: %v", got.Errors)
+		}
+	})
+}
+
+func TestHandleTraceGet(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 9, 23, 23, 7, 32, 0, time.UTC)
+
+	testCases := map[string]struct {
+		mockFunc   func(*usecase.MockIGetTraceUseCase)
+		wantStatus int
+		wantLogs   []HttpLogListResponse
+	}{
+		"Success": {
+			mockFunc: func(m *usecase.MockIGetTraceUseCase) {
+				m.EXPECT().GetTrace(gomock.Any(), testTraceID).Return([]*usecase.ListLogDto{
+					{ID: "log-1", Date: now, TraceID: testTraceID, SpanID: testSpanID},
+					{ID: "log-2", Date: now.Add(time.Second), TraceID: testTraceID, SpanID: "b7ad6b7169203331", ParentSpanID: testSpanID},
+				}, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+			wantLogs: []HttpLogListResponse{
+				{ID: "log-1", Date: now, TraceID: testTraceID, SpanID: testSpanID},
+				{ID: "log-2", Date: now.Add(time.Second), TraceID: testTraceID, SpanID: "b7ad6b7169203331", ParentSpanID: testSpanID},
+			},
+		},
+		"Invalid Trace ID": {
+			mockFunc: func(m *usecase.MockIGetTraceUseCase) {
+				m.EXPECT().GetTrace(gomock.Any(), testTraceID).Return(nil, domain.ErrInvalidTraceID).Times(1)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		"Not Found": {
+			mockFunc: func(m *usecase.MockIGetTraceUseCase) {
+				m.EXPECT().GetTrace(gomock.Any(), testTraceID).Return(nil, domain.ErrTraceNotFound).Times(1)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		"Internal Error": {
+			mockFunc: func(m *usecase.MockIGetTraceUseCase) {
+				m.EXPECT().GetTrace(gomock.Any(), testTraceID).Return(nil, errors.New("db error")).Times(1)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockGetUseCase := usecase.NewMockIGetTraceUseCase(ctrl)
+			tc.mockFunc(mockGetUseCase)
+			handler := NewHttpTraceHandler(mockGetUseCase)
+
+			req := httptest.NewRequest("GET", "/traces/"+testTraceID, nil)
+			req.SetPathValue("trace_id", testTraceID)
+			rr := httptest.NewRecorder()
+
+			handler.HandleTraceGet(rr, req)
+
+			if status := rr.Code; status != tc.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var got []HttpLogListResponse
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantLogs, got); diff != "" {
+				t.Errorf("handler returned unexpected JSON (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
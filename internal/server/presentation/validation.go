@@ -0,0 +1,287 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// maxLogFieldLength is the size of the VARCHAR columns of the logs table.
+	maxLogFieldLength = 100
+	// maxLogContentSize is the size in bytes of the TEXT content column.
+	maxLogContentSize = 65535
+	// maxLogAttributesSize is the largest JSON encoding of the attributes of a log.
+	maxLogAttributesSize = 16 << 10
+
+	// DefaultMaxFutureSkew is how far in the future a log date may be by default.
+	DefaultMaxFutureSkew = 5 * time.Minute
+	// DefaultMaxPastSkew is how far in the past a log date may be by default.
+	DefaultMaxPastSkew = 7 * 24 * time.Hour
+)
+
+// FieldError describes why one field of a request is invalid.
+type FieldError struct {
+	// Field is the JSON name of the field, such as "source_service".
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request has invalid fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + " " + f.Message
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// fieldErrors returns the invalid fields reported by err, if any.
+func fieldErrors(err error) []FieldError {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Fields
+	}
+	return nil
+}
+
+// RequestValidator checks the log and CTR requests received over AMQP and
+// HTTP before they are stored, so that they fit in the database.
+type RequestValidator struct {
+	// MaxFutureSkew is how far after the time of reception the date of a
+	// request may be. Zero disables the check.
+	MaxFutureSkew time.Duration
+	// MaxPastSkew is how far before the time of reception the date of a log
+	// may be. Zero disables the check.
+	MaxPastSkew time.Duration
+	// MaxCTRPastSkew is how far before the time of reception the creation
+	// date of a CTR event may be. Zero, the default, disables the check so
+	// that past events can be backfilled.
+	MaxCTRPastSkew time.Duration
+}
+
+// NewRequestValidatorFromEnv reads the date bounds from LOG_MAX_FUTURE_SKEW,
+// LOG_MAX_PAST_SKEW and CTR_MAX_PAST_SKEW, falling back to the defaults for
+// unset or invalid values.
+func NewRequestValidatorFromEnv() RequestValidator {
+	v := RequestValidator{
+		MaxFutureSkew: DefaultMaxFutureSkew,
+		MaxPastSkew:   DefaultMaxPastSkew,
+	}
+	if d, err := time.ParseDuration(os.Getenv("LOG_MAX_FUTURE_SKEW")); err == nil && d > 0 {
+		v.MaxFutureSkew = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("LOG_MAX_PAST_SKEW")); err == nil && d > 0 {
+		v.MaxPastSkew = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("CTR_MAX_PAST_SKEW")); err == nil && d > 0 {
+		v.MaxCTRPastSkew = d
+	}
+	return v
+}
+
+// fieldChecker accumulates the invalid fields of a request.
+type fieldChecker struct {
+	errs []FieldError
+}
+
+func (c *fieldChecker) fail(field, format string, args ...any) {
+	c.errs = append(c.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// text checks the length in characters of a string field.
+func (c *fieldChecker) text(field, value string, required bool) {
+	switch {
+	case value == "" && required:
+		c.fail(field, "is required")
+	case !utf8.ValidString(value):
+		c.fail(field, "must be valid UTF-8")
+	case utf8.RuneCountInString(value) > maxLogFieldLength:
+		c.fail(field, "must be at most %d characters", maxLogFieldLength)
+	}
+}
+
+// date checks that a required date is within the skew bounds of now. Zero
+// bounds are not checked.
+func (c *fieldChecker) date(field string, date, now time.Time, maxFutureSkew, maxPastSkew time.Duration) {
+	switch {
+	case date.IsZero():
+		c.fail(field, "is required")
+	case maxFutureSkew > 0 && date.After(now.Add(maxFutureSkew)):
+		c.fail(field, "must be at most %s in the future", maxFutureSkew)
+	case maxPastSkew > 0 && date.Before(now.Add(-maxPastSkew)):
+		c.fail(field, "must be at most %s in the past", maxPastSkew)
+	}
+}
+
+func (c *fieldChecker) err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: c.errs}
+}
+
+// ValidateLogRequest checks a log request received at now and returns a
+// *ValidationError listing all its invalid fields.
+//
+// The date, source service and content are required. The level must be an
+// alias of a domain.Severity and the trace context must be made of W3C
+// Trace Context IDs.
+func (v RequestValidator) ValidateLogRequest(req AMQPLogRequest, now time.Time) error {
+	var c fieldChecker
+	if req.LogLevel != "" {
+		if _, err := domain.ParseSeverity(req.LogLevel); err != nil {
+			c.fail("log_level", "must be one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL")
+		}
+	}
+	c.date("date", req.Date, now, v.MaxFutureSkew, v.MaxPastSkew)
+	c.text("source_service", req.SourceService, true)
+	c.text("destination_service", req.DestinationService, false)
+	c.text("request_type", req.RequestType, false)
+	switch {
+	case req.Content == "":
+		c.fail("content", "is required")
+	case !utf8.ValidString(req.Content):
+		c.fail("content", "must be valid UTF-8")
+	case len(req.Content) > maxLogContentSize:
+		c.fail("content", "must be at most %d bytes", maxLogContentSize)
+	}
+	if len(req.Attributes) > 0 {
+		if _, ok := req.Attributes[""]; ok {
+			c.fail("attributes", "must not have an empty key")
+		} else if b, err := json.Marshal(req.Attributes); err != nil || len(b) > maxLogAttributesSize {
+			c.fail("attributes", "must be at most %d bytes once encoded", maxLogAttributesSize)
+		}
+	}
+	switch {
+	case req.TraceID != "":
+		if domain.ValidateTraceID(req.TraceID) != nil {
+			c.fail("trace_id", "must be 32 lowercase hex digits, not all zero")
+		}
+	case req.SpanID != "" || req.ParentSpanID != "":
+		c.fail("trace_id", "is required with span_id and parent_span_id")
+	}
+	if req.SpanID != "" && domain.ValidateSpanID(req.SpanID) != nil {
+		c.fail("span_id", "must be 16 lowercase hex digits, not all zero")
+	}
+	if req.ParentSpanID != "" && domain.ValidateSpanID(req.ParentSpanID) != nil {
+		c.fail("parent_span_id", "must be 16 lowercase hex digits, not all zero")
+	}
+	return c.err()
+}
+
+// ValidateCTRLogRequest checks a CTR event received at now and returns a
+// *ValidationError listing all its invalid fields.
+//
+// The event type must be known, the object ID must fit in the database and
+// the creation date is required, which stampCTRLogRequest ensures.
+func (v RequestValidator) ValidateCTRLogRequest(req AMQPCTRLogRequest, now time.Time) error {
+	var c fieldChecker
+	if req.EventType != domain.CTREventClick && req.EventType != domain.CTREventImpression {
+		c.fail("eventType", "must be %q or %q", domain.CTREventClick, domain.CTREventImpression)
+	}
+	c.text("objectId", req.ObjectID, true)
+	c.date("createdAt", req.CreatedAt, now, v.MaxFutureSkew, v.MaxCTRPastSkew)
+	return c.err()
+}
+
+// stampCTRLogRequest stamps a CTR event without createdAt with the time it
+// was received at, so that events are handled alike over AMQP and HTTP.
+func stampCTRLogRequest(req *AMQPCTRLogRequest, now time.Time) {
+	if req.CreatedAt.IsZero() {
+		req.CreatedAt = now
+	}
+}
+
+// decodeStrict decodes the single JSON value of data into v, rejecting
+// unknown fields and trailing data. Unknown fields and values of the wrong
+// type are reported as a *ValidationError.
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return &ValidationError{Fields: []FieldError{{Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()}}}
+		}
+		// The decoder does not export the name of unknown fields, so it is
+		// looked up in the keys of the objects.
+		if field, ok := unknownField(data, reflect.TypeOf(v)); ok {
+			return &ValidationError{Fields: []FieldError{{Field: field, Message: "is unknown"}}}
+		}
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// unknownField returns the first key, in sorted order, of the JSON object
+// data that does not match a field of the struct typ. For slices, the keys of
+// the objects in the JSON array data are checked against the element type.
+func unknownField(data []byte, typ reflect.Type) (string, bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return "", false
+		}
+		for _, elem := range elems {
+			if field, ok := unknownField(elem, typ.Elem()); ok {
+				return field, true
+			}
+		}
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return "", false
+		}
+		known := jsonFieldNames(typ)
+		for _, key := range slices.Sorted(maps.Keys(object)) {
+			// Like the decoder, match the field names regardless of case.
+			if !slices.ContainsFunc(known, func(name string) bool { return strings.EqualFold(name, key) }) {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// jsonFieldNames returns the names under which the exported fields of the
+// struct typ are decoded.
+func jsonFieldNames(typ reflect.Type) []string {
+	var names []string
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
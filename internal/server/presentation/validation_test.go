@@ -0,0 +1,248 @@
+package presentation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateLogRequest(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 9, 23, 12, 0, 0, 0, time.UTC)
+	v := RequestValidator{MaxFutureSkew: time.Minute, MaxPastSkew: time.Hour}
+	valid := func() AMQPLogRequest {
+		return AMQPLogRequest{
+			LogLevel:      "warning",
+			Date:          now,
+			SourceService: "AuthService",
+			Content:       "login failed",
+		}
+	}
+
+	testCases := map[string]struct {
+		modify     func(*AMQPLogRequest)
+		wantFields []FieldError
+	}{
+		"valid": {
+			modify: func(*AMQPLogRequest) {},
+		},
+		"valid with optional fields": {
+			modify: func(req *AMQPLogRequest) {
+				req.DestinationService = "UserService"
+				req.RequestType = "POST"
+				req.Attributes = map[string]any{"status": 401}
+				req.TraceID = testTraceID
+				req.SpanID = testSpanID
+			},
+		},
+		"missing fields": {
+			modify: func(req *AMQPLogRequest) { *req = AMQPLogRequest{} },
+			wantFields: []FieldError{
+				{Field: "date", Message: "is required"},
+				{Field: "source_service", Message: "is required"},
+				{Field: "content", Message: "is required"},
+			},
+		},
+		"unknown level": {
+			modify:     func(req *AMQPLogRequest) { req.LogLevel = "verbose" },
+			wantFields: []FieldError{{Field: "log_level", Message: "must be one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL"}},
+		},
+		"date in the future": {
+			modify:     func(req *AMQPLogRequest) { req.Date = now.Add(2 * time.Minute) },
+			wantFields: []FieldError{{Field: "date", Message: "must be at most 1m0s in the future"}},
+		},
+		"date in the past": {
+			modify:     func(req *AMQPLogRequest) { req.Date = now.Add(-2 * time.Hour) },
+			wantFields: []FieldError{{Field: "date", Message: "must be at most 1h0m0s in the past"}},
+		},
+		"long service names": {
+			modify: func(req *AMQPLogRequest) {
+				req.SourceService = strings.Repeat("é", maxLogFieldLength+1)
+				req.RequestType = strings.Repeat("a", maxLogFieldLength+1)
+			},
+			wantFields: []FieldError{
+				{Field: "source_service", Message: "must be at most 100 characters"},
+				{Field: "request_type", Message: "must be at most 100 characters"},
+			},
+		},
+		"multi-byte names within limit": {
+			modify: func(req *AMQPLogRequest) { req.SourceService = strings.Repeat("é", maxLogFieldLength) },
+		},
+		"large content": {
+			modify:     func(req *AMQPLogRequest) { req.Content = strings.Repeat("a", maxLogContentSize+1) },
+			wantFields: []FieldError{{Field: "content", Message: "must be at most 65535 bytes"}},
+		},
+		"invalid UTF-8": {
+			modify:     func(req *AMQPLogRequest) { req.Content = "\xff" },
+			wantFields: []FieldError{{Field: "content", Message: "must be valid UTF-8"}},
+		},
+		"large attributes": {
+			modify: func(req *AMQPLogRequest) {
+				req.Attributes = map[string]any{"body": strings.Repeat("a", maxLogAttributesSize)}
+			},
+			wantFields: []FieldError{{Field: "attributes", Message: "must be at most 16384 bytes once encoded"}},
+		},
+		"empty attribute key": {
+			modify:     func(req *AMQPLogRequest) { req.Attributes = map[string]any{"": 1} },
+			wantFields: []FieldError{{Field: "attributes", Message: "must not have an empty key"}},
+		},
+		"invalid trace context": {
+			modify: func(req *AMQPLogRequest) {
+				req.TraceID = "xyz"
+				req.ParentSpanID = strings.Repeat("0", 16)
+			},
+			wantFields: []FieldError{
+				{Field: "trace_id", Message: "must be 32 lowercase hex digits, not all zero"},
+				{Field: "parent_span_id", Message: "must be 16 lowercase hex digits, not all zero"},
+			},
+		},
+		"span without trace": {
+			modify:     func(req *AMQPLogRequest) { req.SpanID = testSpanID },
+			wantFields: []FieldError{{Field: "trace_id", Message: "is required with span_id and parent_span_id"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := valid()
+			tc.modify(&req)
+
+			err := v.ValidateLogRequest(req, now)
+			if diff := cmp.Diff(tc.wantFields, fieldErrors(err)); diff != "" {
+				t.Errorf("ValidateLogRequest() field errors mismatch (-want +got):\n%s", diff)
+			}
+			if (err != nil) != (tc.wantFields != nil) {
+				t.Errorf("ValidateLogRequest() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCTRLogRequest(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 9, 23, 12, 0, 0, 0, time.UTC)
+	v := RequestValidator{MaxFutureSkew: time.Minute, MaxPastSkew: time.Hour}
+
+	testCases := map[string]struct {
+		validator  *RequestValidator
+		req        AMQPCTRLogRequest
+		wantFields []FieldError
+	}{
+		"valid": {
+			req: AMQPCTRLogRequest{EventType: "click", ObjectID: "banner", CreatedAt: now},
+		},
+		"backfill beyond the log past skew": {
+			req: AMQPCTRLogRequest{EventType: "impression", ObjectID: "banner", CreatedAt: now.AddDate(-1, 0, 0)},
+		},
+		"too far in the past": {
+			validator: &RequestValidator{MaxCTRPastSkew: 24 * time.Hour},
+			req:       AMQPCTRLogRequest{EventType: "impression", ObjectID: "banner", CreatedAt: now.AddDate(0, 0, -2)},
+			wantFields: []FieldError{
+				{Field: "createdAt", Message: "must be at most 24h0m0s in the past"},
+			},
+		},
+		"invalid": {
+			req: AMQPCTRLogRequest{EventType: "hover", ObjectID: strings.Repeat("a", 101), CreatedAt: now.Add(time.Hour)},
+			wantFields: []FieldError{
+				{Field: "eventType", Message: `must be "click" or "impression"`},
+				{Field: "objectId", Message: "must be at most 100 characters"},
+				{Field: "createdAt", Message: "must be at most 1m0s in the future"},
+			},
+		},
+		"missing fields": {
+			req: AMQPCTRLogRequest{EventType: "click"},
+			wantFields: []FieldError{
+				{Field: "objectId", Message: "is required"},
+				{Field: "createdAt", Message: "is required"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			v := v
+			if tc.validator != nil {
+				v = *tc.validator
+			}
+			err := v.ValidateCTRLogRequest(tc.req, now)
+			if diff := cmp.Diff(tc.wantFields, fieldErrors(err)); diff != "" {
+				t.Errorf("ValidateCTRLogRequest() field errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		body       string
+		wantErr    bool
+		wantFields []FieldError
+	}{
+		"valid":         {body: `{"content":"c"}`},
+		"unknown field": {body: `{"content":"c","level":"INFO"}`, wantErr: true, wantFields: []FieldError{{Field: "level", Message: "is unknown"}}},
+		"wrong type":    {body: `{"content":1}`, wantErr: true, wantFields: []FieldError{{Field: "content", Message: "must be a string"}}},
+		"trailing data": {body: `{"content":"c"} {}`, wantErr: true},
+		"syntax error":  {body: `{"content":`, wantErr: true},
+		"empty":         {body: ``, wantErr: true},
+		"unknown field differing in case only from a known one": {
+			body: `{"Content":"c","SOURCE":"s"}`, wantErr: true, wantFields: []FieldError{{Field: "SOURCE", Message: "is unknown"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var req AMQPLogRequest
+			err := decodeStrict([]byte(tc.body), &req)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("decodeStrict() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFields, fieldErrors(err)); diff != "" {
+				t.Errorf("decodeStrict() field errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeStrictArray(t *testing.T) {
+	t.Parallel()
+	var reqs []AMQPCTRLogRequest
+	err := decodeStrict([]byte(`[{"eventType":"click","objectId":"a"},{"eventType":"click","object":"b"}]`), &reqs)
+	want := []FieldError{{Field: "object", Message: "is unknown"}}
+	if diff := cmp.Diff(want, fieldErrors(err)); diff != "" {
+		t.Errorf("decodeStrict() field errors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewRequestValidatorFromEnv(t *testing.T) {
+	t.Setenv("LOG_MAX_FUTURE_SKEW", "1m")
+	t.Setenv("LOG_MAX_PAST_SKEW", "invalid")
+	t.Setenv("CTR_MAX_PAST_SKEW", "720h")
+
+	v := NewRequestValidatorFromEnv()
+	want := RequestValidator{MaxFutureSkew: time.Minute, MaxPastSkew: DefaultMaxPastSkew, MaxCTRPastSkew: 720 * time.Hour}
+	if v != want {
+		t.Errorf("NewRequestValidatorFromEnv() = %+v, want %+v", v, want)
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	t.Parallel()
+	err := error(&ValidationError{Fields: []FieldError{
+		{Field: "date", Message: "is required"},
+		{Field: "content", Message: "is required"},
+	}})
+	if got, want := err.Error(), "invalid request: date is required; content is required"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Errorf("errors.As() did not find the ValidationError")
+	}
+}
@@ -5,17 +5,22 @@ import (
 	"database/sql"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/rabbitmq/amqp091-go"
-
+	"log_service/internal/server/infrastructure/batch"
 	"log_service/internal/server/infrastructure/di"
 	"log_service/internal/server/infrastructure/rabbitmq"
 	"log_service/internal/server/presentation"
+	"log_service/internal/server/usecase"
 )
 
+// defaultDrainTimeout is how long shutdown waits for the in-flight HTTP
+// requests and AMQP deliveries when DRAIN_TIMEOUT is unset.
+const defaultDrainTimeout = 5 * time.Second
+
 func Run() error {
 	ctx, stop := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGTERM)
@@ -28,31 +33,58 @@ func Run() error {
 
 	err = c.Invoke(func(
 		dbConn *sql.DB,
-		amqpConn *amqp091.Connection,
-		amqpCh *amqp091.Channel,
-		amqpCTRMsgs <-chan amqp091.Delivery,
+		amqpSupervisor *rabbitmq.Supervisor,
+		logWriter *batch.LogWriter,
 		amqpLogHandler *presentation.AMQPLogHandler,
 		amqpCtrLogHandler *presentation.AMQPCTRLogHandler,
 		httpLogHander *presentation.HttpLogHandler,
+		httpCTRLogHandler *presentation.HttpCTRLogHandler,
+		httpTraceHandler *presentation.HttpTraceHandler,
+		httpServiceGraphHandler *presentation.HttpServiceGraphHandler,
+		httpDeadLetterHandler *presentation.HttpDeadLetterHandler,
+		httpHealthHandler *presentation.HttpHealthHandler,
+		corsConfig presentation.CORSConfig,
+		adminConfig presentation.AdminConfig,
+		compactCTRStatsUseCase *usecase.CompactCTRStatsUseCase,
+		logHub *usecase.LogHub,
 	) {
 		defer dbConn.Close()
-		defer amqpCh.Close()
-		defer amqpConn.Close()
+		defer amqpSupervisor.Close()
+		// Flush the buffered logs before the database connection is closed.
+		defer logWriter.Close()
 
-		done := make(chan bool)
-		go func() {
-			for d := range amqpCTRMsgs {
-				amqpCtrLogHandler.HandleCTRLog(d)
-			}
-		}()
+		amqpSupervisor.Consume(rabbitmq.LOG_QUEUE_NAME, rabbitmq.LOG_CONSUMER_TAG, amqpLogHandler.HandleLog)
+		amqpSupervisor.Consume(rabbitmq.CTR_LOG_QUEUE_NAME, rabbitmq.CTR_LOG_CONSUMER_TAG, amqpCtrLogHandler.HandleCTRLog)
+		amqpSupervisor.Start()
+		go compactCTRStats(ctx, compactCTRStatsUseCase)
 
 		mux := http.NewServeMux()
-		mux.HandleFunc("/logs", httpLogHander.HandleLogList)
+		mux.HandleFunc("GET /healthz", httpHealthHandler.HandleHealth)
+		mux.HandleFunc("GET /logs", httpLogHander.HandleLogList)
+		mux.HandleFunc("POST /logs", httpLogHander.HandleLogCreate)
+		mux.HandleFunc("GET /logs/stream", httpLogHander.HandleLogStream)
+		mux.HandleFunc("GET /logs/{id}", httpLogHander.HandleLogGet)
+		mux.HandleFunc("GET /traces/{trace_id}", httpTraceHandler.HandleTraceGet)
+		mux.HandleFunc("GET /services/graph", httpServiceGraphHandler.HandleServiceGraph)
+
+		ctrHandler := presentation.CORS(corsConfig, http.HandlerFunc(httpCTRLogHandler.HandleCTRLogCreate))
+		mux.Handle("POST /ctr", ctrHandler)
+		mux.Handle("OPTIONS /ctr", ctrHandler)
+		mux.HandleFunc("GET /ctr/stats", httpCTRLogHandler.HandleCTRStats)
+
+		adminMux := http.NewServeMux()
+		adminMux.HandleFunc("GET /admin/dlq/{queue}", httpDeadLetterHandler.HandleDeadLetterList)
+		adminMux.HandleFunc("GET /admin/dlq/{queue}/{id}", httpDeadLetterHandler.HandleDeadLetterGet)
+		adminMux.HandleFunc("POST /admin/dlq/{queue}/replay", httpDeadLetterHandler.HandleDeadLetterReplay)
+		adminMux.HandleFunc("POST /admin/dlq/{queue}/purge", httpDeadLetterHandler.HandleDeadLetterPurge)
+		mux.Handle("/admin/", presentation.AdminAuth(adminConfig, adminMux))
 
 		srv := &http.Server{
 			Addr:    ":8080",
 			Handler: mux,
 		}
+		// End the live streams, which would otherwise keep Shutdown waiting.
+		srv.RegisterOnShutdown(logHub.Close)
 
 		go func() {
 			log.Printf("HTTP server is running on %s", srv.Addr)
@@ -69,26 +101,26 @@ func Run() error {
 		log.Println("received sigint/sigterm, shutting down...")
 		log.Println("press Ctrl^C again to force shutdown")
 
-		if err := amqpCh.Cancel(rabbitmq.QUEUE_NAME, false); err != nil {
-			log.Fatalf("Failed to cancel consumer: %v", err)
-		}
-		if err := amqpCh.Close(); err != nil {
-			log.Fatalf("Failed to close channel: %v", err)
-		}
-
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeoutFromEnv())
 		defer cancel()
 
-		if err := srv.Shutdown(shutdownCtx); err != nil {
-			log.Fatalf("HTTP server Shutdown: %v", err)
+		// Stop taking new work over AMQP and HTTP at once, then wait for the
+		// work in progress. Drain stops the consumers itself.
+		drained := make(chan error, 1)
+		go func() { drained <- amqpSupervisor.Drain(drainCtx) }()
+
+		if err := srv.Shutdown(drainCtx); err != nil {
+			log.Printf("HTTP server Shutdown: %v", err)
+		} else {
+			log.Println("HTTP server gracefully stopped")
 		}
-		log.Println("HTTP server gracefully stopped")
 
-		select {
-		case <-done:
+		// The deferred logWriter.Close then flushes the batches of the
+		// handlers that did not finish in time.
+		if err := <-drained; err != nil {
+			log.Printf("Failed to drain in-flight messages: %v", err)
+		} else {
 			log.Println("finished processing all jobs")
-		case <-time.After(5 * time.Second):
-			log.Println("timed out waiting for jobs to finish")
 		}
 	})
 
@@ -98,3 +130,28 @@ func Run() error {
 
 	return nil
 }
+
+// compactCTRStats compacts the CTR counts at the configured interval until ctx is done.
+func compactCTRStats(ctx context.Context, uc *usecase.CompactCTRStatsUseCase) {
+	ticker := time.NewTicker(uc.Interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := uc.CompactCTRStats(ctx); err != nil {
+				log.Printf("Failed to compact CTR stats: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// drainTimeoutFromEnv reads the shutdown drain timeout from DRAIN_TIMEOUT,
+// falling back to defaultDrainTimeout for unset or invalid values.
+func drainTimeoutFromEnv() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv("DRAIN_TIMEOUT")); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultDrainTimeout
+}
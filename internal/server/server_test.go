@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrainTimeoutFromEnv(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		want  time.Duration
+	}{
+		"unset":    {value: "", want: defaultDrainTimeout},
+		"valid":    {value: "30s", want: 30 * time.Second},
+		"invalid":  {value: "soon", want: defaultDrainTimeout},
+		"negative": {value: "-1s", want: defaultDrainTimeout},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("DRAIN_TIMEOUT", tc.value)
+			if got := drainTimeoutFromEnv(); got != tc.want {
+				t.Errorf("drainTimeoutFromEnv() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// DefaultCTRStatsMinuteRetention is the default time per-minute CTR counts are kept.
+	DefaultCTRStatsMinuteRetention = 48 * time.Hour
+	// DefaultCTRStatsHourRetention is the default time per-hour CTR counts are kept.
+	DefaultCTRStatsHourRetention = 90 * 24 * time.Hour
+	// DefaultCTRStatsCompactionInterval is the default time between two compactions.
+	DefaultCTRStatsCompactionInterval = time.Hour
+)
+
+// CTRStatsCompactionConfig controls how long the CTR counts of each bucket
+// width are kept. The per-day counts are kept forever.
+type CTRStatsCompactionConfig struct {
+	MinuteRetention time.Duration
+	HourRetention   time.Duration
+	// Interval is the time between two compactions.
+	Interval time.Duration
+}
+
+// NewCTRStatsCompactionConfigFromEnv reads the configuration from
+// CTR_STATS_MINUTE_RETENTION, CTR_STATS_HOUR_RETENTION and
+// CTR_STATS_COMPACTION_INTERVAL, falling back to the defaults for unset or
+// invalid values.
+func NewCTRStatsCompactionConfigFromEnv() CTRStatsCompactionConfig {
+	return CTRStatsCompactionConfig{
+		MinuteRetention: durationFromEnv("CTR_STATS_MINUTE_RETENTION", DefaultCTRStatsMinuteRetention),
+		HourRetention:   durationFromEnv("CTR_STATS_HOUR_RETENTION", DefaultCTRStatsHourRetention),
+		Interval:        durationFromEnv("CTR_STATS_COMPACTION_INTERVAL", DefaultCTRStatsCompactionInterval),
+	}
+}
+
+// Retention returns how long the counts of bucket are kept, or 0 if they are
+// kept forever.
+func (c CTRStatsCompactionConfig) Retention(bucket domain.CTRBucket) time.Duration {
+	switch bucket {
+	case domain.CTRBucketMinute:
+		return c.MinuteRetention
+	case domain.CTRBucketHour:
+		return c.HourRetention
+	default:
+		return 0
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// ICompactCTRStatsUseCase is an interface for compacting the CTR counts.
+type ICompactCTRStatsUseCase interface {
+	CompactCTRStats(ctx context.Context) error
+}
+
+// CompactCTRStatsUseCase is a use case for deleting the fine-grained CTR
+// counts once they are older than their retention.
+//
+// Every CTR event is counted per minute, per hour and per day when it is
+// stored, so the coarser counts already hold everything the deleted ones did.
+type CompactCTRStatsUseCase struct {
+	logRepository domain.ILogRepository
+	config        CTRStatsCompactionConfig
+}
+
+// NewCompactCTRStatsUseCase creates a new instance of CompactCTRStatsUseCase
+// with the given log repository and configuration.
+func NewCompactCTRStatsUseCase(logRepository domain.ILogRepository, config CTRStatsCompactionConfig) *CompactCTRStatsUseCase {
+	return &CompactCTRStatsUseCase{
+		logRepository: logRepository,
+		config:        config,
+	}
+}
+
+// CompactCTRStats deletes the per-minute and per-hour counts older than their retention.
+func (u *CompactCTRStatsUseCase) CompactCTRStats(ctx context.Context) error {
+	now := time.Now()
+	_, minuteErr := u.logRepository.DeleteCTRStats(ctx, domain.CTRBucketMinute, now.Add(-u.config.Retention(domain.CTRBucketMinute)))
+	_, hourErr := u.logRepository.DeleteCTRStats(ctx, domain.CTRBucketHour, now.Add(-u.config.Retention(domain.CTRBucketHour)))
+	return errors.Join(minuteErr, hourErr)
+}
+
+// Interval returns the time between two compactions.
+func (u *CompactCTRStatsUseCase) Interval() time.Duration {
+	return u.config.Interval
+}
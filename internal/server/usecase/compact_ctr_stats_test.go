@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestCompactCTRStats(t *testing.T) {
+	t.Parallel()
+	config := CTRStatsCompactionConfig{MinuteRetention: time.Hour, HourRetention: 24 * time.Hour}
+
+	// before matches a cutoff the given retention before the call.
+	before := func(retention time.Duration) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			cutoff := x.(time.Time)
+			d := time.Since(cutoff) - retention
+			return d >= 0 && d < time.Minute
+		})
+	}
+
+	testCases := map[string]struct {
+		minuteErr error
+		hourErr   error
+		wantErr   bool
+	}{
+		"success": {},
+		"minute failure": {
+			minuteErr: errors.New("failed to delete"),
+			wantErr:   true,
+		},
+		"hour failure": {
+			hourErr: errors.New("failed to delete"),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			compactUseCase := NewCompactCTRStatsUseCase(mockRepo, config)
+
+			// A failure to delete one bucket width does not prevent the other from being compacted.
+			mockRepo.EXPECT().DeleteCTRStats(gomock.Any(), domain.CTRBucketMinute, before(config.MinuteRetention)).Return(int64(3), tc.minuteErr).Times(1)
+			mockRepo.EXPECT().DeleteCTRStats(gomock.Any(), domain.CTRBucketHour, before(config.HourRetention)).Return(int64(1), tc.hourErr).Times(1)
+
+			err := compactUseCase.CompactCTRStats(context.Background())
+			if tc.wantErr && err == nil {
+				t.Errorf("CompactCTRStats() expected error but got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("CompactCTRStats() unexpected error = %v", err)
+			}
+		})
+	}
+}
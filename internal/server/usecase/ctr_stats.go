@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// DefaultCTRStatsLimit is the number of objects returned when no limit is requested.
+	DefaultCTRStatsLimit = 10
+	// MaxCTRStatsLimit is the largest number of objects that can be requested.
+	MaxCTRStatsLimit = 1000
+	// DefaultCTRStatsRange is the time range covered when no start is requested.
+	DefaultCTRStatsRange = 24 * time.Hour
+)
+
+// maxCTRStatsRange bounds the time range per bucket so that a single request
+// cannot return an unbounded number of buckets per object.
+var maxCTRStatsRange = map[domain.CTRBucket]time.Duration{
+	domain.CTRBucketMinute: 24 * time.Hour,
+	domain.CTRBucketHour:   31 * 24 * time.Hour,
+	domain.CTRBucketDay:    366 * 24 * time.Hour,
+}
+
+var (
+	// ErrInvalidBucket is returned when the requested time bucket is unknown.
+	ErrInvalidBucket = errors.New("invalid bucket")
+	// ErrInvalidTimeRange is returned when the requested time range is empty,
+	// too long, or starts before the counts of its bucket were compacted.
+	ErrInvalidTimeRange = errors.New("invalid time range")
+	// ErrInvalidCTRStatsSort is returned when the requested ranking measure is unknown.
+	ErrInvalidCTRStatsSort = errors.New("invalid sort")
+	// ErrInvalidLimit is returned when the requested number of objects is out of range.
+	ErrInvalidLimit = errors.New("invalid limit")
+)
+
+// ICTRStatsUseCase is an interface for computing click-through rates.
+type ICTRStatsUseCase interface {
+	GetCTRStats(ctx context.Context, query *CTRStatsQueryDto) ([]*CTRObjectStatsDto, error)
+}
+
+// CTRStatsUseCase is a use case for computing click-through rates from the stored CTR events.
+type CTRStatsUseCase struct {
+	logRepository domain.ILogRepository
+	// compaction tells how far back the counts of each bucket go.
+	compaction CTRStatsCompactionConfig
+}
+
+// NewCTRStatsUseCase creates a new instance of CTRStatsUseCase with the given
+// log repository and the configuration of the compaction of the counts.
+func NewCTRStatsUseCase(logRepository domain.ILogRepository, compaction CTRStatsCompactionConfig) *CTRStatsUseCase {
+	return &CTRStatsUseCase{
+		logRepository: logRepository,
+		compaction:    compaction,
+	}
+}
+
+// CTRStatsQueryDto is a data transfer object describing which statistics to compute.
+//
+// To defaults to now, From defaults to DefaultCTRStatsRange before To, Bucket
+// defaults to "hour", Sort defaults to "impressions" and Limit defaults to
+// DefaultCTRStatsLimit. From is rounded down to the start of its bucket, and
+// must not be older than the retention of the bucket's counts.
+type CTRStatsQueryDto struct {
+	From   time.Time
+	To     time.Time
+	Bucket string
+	Sort   string
+	Limit  int
+}
+
+// CTRStatsDto is the number of impressions and clicks and the resulting
+// click-through rate over a period of time.
+type CTRStatsDto struct {
+	Impressions int64
+	Clicks      int64
+	// CTR is Clicks divided by Impressions, or 0 without impressions.
+	CTR float64
+}
+
+// CTRBucketStatsDto is the statistics of one time bucket.
+type CTRBucketStatsDto struct {
+	Start time.Time
+	CTRStatsDto
+}
+
+// CTRObjectStatsDto is the statistics of one object over the whole time
+// range, along with its statistics per time bucket in chronological order.
+// Buckets without events are omitted.
+type CTRObjectStatsDto struct {
+	ObjectID string
+	CTRStatsDto
+	Buckets []CTRBucketStatsDto
+}
+
+// GetCTRStats returns the top objects by the requested measure with their
+// statistics over the requested time range. Ties are broken by object ID.
+func (u *CTRStatsUseCase) GetCTRStats(ctx context.Context, query *CTRStatsQueryDto) ([]*CTRObjectStatsDto, error) {
+	filter, err := newCTRStatsFilter(query, u.compaction, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := domain.CTRStatsSort(query.Sort)
+	switch sortBy {
+	case "":
+		sortBy = domain.CTRStatsSortImpressions
+	case domain.CTRStatsSortImpressions, domain.CTRStatsSortClicks, domain.CTRStatsSortCTR:
+	default:
+		return nil, ErrInvalidCTRStatsSort
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = DefaultCTRStatsLimit
+	}
+	if limit < 0 || limit > MaxCTRStatsLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	// The objects are ranked by the database, so that the buckets of the top
+	// objects only are read.
+	totals, err := u.logRepository.CTRTopObjects(ctx, filter, sortBy, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) == 0 {
+		return nil, nil
+	}
+
+	objects := make([]*CTRObjectStatsDto, 0, len(totals))
+	byID := make(map[string]*CTRObjectStatsDto, len(totals))
+	bucketFilter := *filter
+	for _, total := range totals {
+		object := &CTRObjectStatsDto{
+			ObjectID:    total.ObjectID,
+			CTRStatsDto: newCTRStatsDto(total.Impressions, total.Clicks),
+		}
+		objects = append(objects, object)
+		byID[total.ObjectID] = object
+		bucketFilter.ObjectIDs = append(bucketFilter.ObjectIDs, total.ObjectID)
+	}
+
+	stats, err := u.logRepository.CTRStats(ctx, &bucketFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, stat := range stats {
+		object, ok := byID[stat.ObjectID]
+		if !ok {
+			continue
+		}
+		object.Buckets = append(object.Buckets, CTRBucketStatsDto{
+			Start:       stat.BucketStart,
+			CTRStatsDto: newCTRStatsDto(stat.Impressions, stat.Clicks),
+		})
+	}
+	for _, object := range objects {
+		slices.SortFunc(object.Buckets, func(a, b CTRBucketStatsDto) int {
+			return a.Start.Compare(b.Start)
+		})
+	}
+
+	return objects, nil
+}
+
+// newCTRStatsFilter validates the time range and bucket of the query and
+// applies their defaults. A range starting before the counts of its bucket
+// were compacted is rejected rather than answered with partial counts.
+func newCTRStatsFilter(query *CTRStatsQueryDto, compaction CTRStatsCompactionConfig, now time.Time) (*domain.CTRStatsFilter, error) {
+	filter := &domain.CTRStatsFilter{
+		From:   query.From,
+		To:     query.To,
+		Bucket: domain.CTRBucket(query.Bucket),
+	}
+	if filter.Bucket == "" {
+		filter.Bucket = domain.CTRBucketHour
+	}
+	maxRange, ok := maxCTRStatsRange[filter.Bucket]
+	if !ok {
+		return nil, ErrInvalidBucket
+	}
+
+	if filter.To.IsZero() {
+		filter.To = now
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-DefaultCTRStatsRange)
+	}
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > maxRange {
+		return nil, ErrInvalidTimeRange
+	}
+	if retention := compaction.Retention(filter.Bucket); retention > 0 && filter.From.Before(now.Add(-retention)) {
+		return nil, ErrInvalidTimeRange
+	}
+	filter.From = filter.Bucket.Truncate(filter.From)
+	return filter, nil
+}
+
+func newCTRStatsDto(impressions, clicks int64) CTRStatsDto {
+	stats := CTRStatsDto{Impressions: impressions, Clicks: clicks}
+	if impressions > 0 {
+		stats.CTR = float64(clicks) / float64(impressions)
+	}
+	return stats
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestGetCTRStats(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	testCases := map[string]struct {
+		sort      string
+		limit     int
+		wantSort  domain.CTRStatsSort
+		wantLimit int
+	}{
+		"defaults": {
+			wantSort:  domain.CTRStatsSortImpressions,
+			wantLimit: DefaultCTRStatsLimit,
+		},
+		"by clicks": {
+			sort:      "clicks",
+			limit:     5,
+			wantSort:  domain.CTRStatsSortClicks,
+			wantLimit: 5,
+		},
+		"by ctr": {
+			sort:      "ctr",
+			limit:     1,
+			wantSort:  domain.CTRStatsSortCTR,
+			wantLimit: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{})
+
+			filter := &domain.CTRStatsFilter{From: from, To: to, Bucket: domain.CTRBucketHour}
+			mockRepo.EXPECT().CTRTopObjects(gomock.Any(), filter, tc.wantSort, tc.wantLimit).Return([]domain.CTRTotal{
+				{ObjectID: "b", Impressions: 20, Clicks: 10},
+				{ObjectID: "a", Impressions: 40, Clicks: 2},
+			}, nil).Times(1)
+			mockRepo.EXPECT().CTRStats(gomock.Any(), &domain.CTRStatsFilter{
+				From:      from,
+				To:        to,
+				Bucket:    domain.CTRBucketHour,
+				ObjectIDs: []string{"b", "a"},
+			}).Return([]domain.CTRStat{
+				{ObjectID: "a", BucketStart: from, Impressions: 40, Clicks: 2},
+				{ObjectID: "b", BucketStart: from, Impressions: 20, Clicks: 10},
+			}, nil).Times(1)
+
+			results, err := ctrStatsUseCase.GetCTRStats(context.Background(), &CTRStatsQueryDto{
+				From:  from,
+				To:    to,
+				Sort:  tc.sort,
+				Limit: tc.limit,
+			})
+			if err != nil {
+				t.Fatalf("GetCTRStats() unexpected error = %v", err)
+			}
+
+			// The objects keep the ranking of the repository.
+			var gotIDs []string
+			for _, result := range results {
+				gotIDs = append(gotIDs, result.ObjectID)
+				if len(result.Buckets) != 1 || result.Buckets[0].Impressions != result.Impressions {
+					t.Errorf("GetCTRStats() unexpected buckets of %s %+v", result.ObjectID, result.Buckets)
+				}
+			}
+			if len(gotIDs) != 2 || gotIDs[0] != "b" || gotIDs[1] != "a" {
+				t.Fatalf("GetCTRStats() expected objects [b a], got %v", gotIDs)
+			}
+		})
+	}
+}
+
+func TestGetCTRStatsTotals(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{})
+
+	mockRepo.EXPECT().CTRTopObjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.CTRTotal{
+		{ObjectID: "a", Impressions: 10, Clicks: 1},
+	}, nil).Times(1)
+	mockRepo.EXPECT().CTRStats(gomock.Any(), gomock.Any()).Return([]domain.CTRStat{
+		{ObjectID: "a", BucketStart: from.Add(24 * time.Hour), Impressions: 4, Clicks: 1},
+		{ObjectID: "a", BucketStart: from, Impressions: 6, Clicks: 0},
+	}, nil).Times(1)
+
+	results, err := ctrStatsUseCase.GetCTRStats(context.Background(), &CTRStatsQueryDto{
+		From:   from,
+		To:     from.Add(48 * time.Hour),
+		Bucket: "day",
+	})
+	if err != nil {
+		t.Fatalf("GetCTRStats() unexpected error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("GetCTRStats() expected 1 object, got %d", len(results))
+	}
+
+	got := results[0]
+	if got.Impressions != 10 || got.Clicks != 1 || got.CTR != 0.1 {
+		t.Errorf("GetCTRStats() unexpected totals %+v", got.CTRStatsDto)
+	}
+	if len(got.Buckets) != 2 || !got.Buckets[0].Start.Equal(from) || got.Buckets[1].CTR != 0.25 {
+		t.Errorf("GetCTRStats() unexpected buckets %+v", got.Buckets)
+	}
+}
+
+func TestGetCTRStatsNoObjects(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{})
+
+	// The buckets are not read without objects.
+	mockRepo.EXPECT().CTRTopObjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	results, err := ctrStatsUseCase.GetCTRStats(context.Background(), &CTRStatsQueryDto{})
+	if err != nil || len(results) != 0 {
+		t.Errorf("GetCTRStats() = %v, %v, want no objects", results, err)
+	}
+}
+
+func TestGetCTRStatsInvalidQuery(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+
+	testCases := map[string]struct {
+		query   *CTRStatsQueryDto
+		wantErr error
+	}{
+		"unknown bucket": {
+			query:   &CTRStatsQueryDto{Bucket: "week"},
+			wantErr: ErrInvalidBucket,
+		},
+		"empty time range": {
+			query:   &CTRStatsQueryDto{From: now, To: now},
+			wantErr: ErrInvalidTimeRange,
+		},
+		"time range too long": {
+			query:   &CTRStatsQueryDto{From: now.AddDate(0, -2, 0), To: now, Bucket: "hour"},
+			wantErr: ErrInvalidTimeRange,
+		},
+		"minute counts compacted": {
+			query:   &CTRStatsQueryDto{From: now.Add(-72 * time.Hour), To: now.Add(-71 * time.Hour), Bucket: "minute"},
+			wantErr: ErrInvalidTimeRange,
+		},
+		"hour counts compacted": {
+			query:   &CTRStatsQueryDto{From: now.AddDate(0, 0, -100), To: now.AddDate(0, 0, -99), Bucket: "hour"},
+			wantErr: ErrInvalidTimeRange,
+		},
+		"unknown sort": {
+			query:   &CTRStatsQueryDto{Sort: "views"},
+			wantErr: ErrInvalidCTRStatsSort,
+		},
+		"limit too large": {
+			query:   &CTRStatsQueryDto{Limit: MaxCTRStatsLimit + 1},
+			wantErr: ErrInvalidLimit,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{
+				MinuteRetention: DefaultCTRStatsMinuteRetention,
+				HourRetention:   DefaultCTRStatsHourRetention,
+			})
+
+			_, err := ctrStatsUseCase.GetCTRStats(context.Background(), tc.query)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("GetCTRStats() expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("ranking failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockRepo := domain.NewMockILogRepository(ctrl)
+		ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{})
+		mockRepo.EXPECT().CTRTopObjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to rank")).Times(1)
+
+		if _, err := ctrStatsUseCase.GetCTRStats(context.Background(), &CTRStatsQueryDto{}); err == nil {
+			t.Errorf("GetCTRStats() expected error but got none")
+		}
+	})
+
+	t.Run("repository failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockRepo := domain.NewMockILogRepository(ctrl)
+		ctrStatsUseCase := NewCTRStatsUseCase(mockRepo, CTRStatsCompactionConfig{})
+		mockRepo.EXPECT().CTRTopObjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.CTRTotal{{ObjectID: "a"}}, nil).Times(1)
+		mockRepo.EXPECT().CTRStats(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to count")).Times(1)
+
+		if _, err := ctrStatsUseCase.GetCTRStats(context.Background(), &CTRStatsQueryDto{}); err == nil {
+			t.Errorf("GetCTRStats() expected error but got none")
+		}
+	})
+}
+
+func TestNewCTRStatsFilterRetention(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 9, 23, 12, 0, 0, 0, time.UTC)
+	compaction := CTRStatsCompactionConfig{
+		MinuteRetention: DefaultCTRStatsMinuteRetention,
+		HourRetention:   DefaultCTRStatsHourRetention,
+	}
+
+	testCases := map[string]struct {
+		compaction CTRStatsCompactionConfig
+		from       time.Time
+		bucket     string
+		wantErr    error
+	}{
+		"minute counts kept":      {compaction: compaction, from: now.Add(-47 * time.Hour), bucket: "minute"},
+		"minute counts compacted": {compaction: compaction, from: now.Add(-49 * time.Hour), bucket: "minute", wantErr: ErrInvalidTimeRange},
+		"hour counts kept":        {compaction: compaction, from: now.AddDate(0, 0, -89), bucket: "hour"},
+		"hour counts compacted":   {compaction: compaction, from: now.AddDate(0, 0, -91), bucket: "hour", wantErr: ErrInvalidTimeRange},
+		"day counts kept forever": {compaction: compaction, from: now.AddDate(-5, 0, 0), bucket: "day"},
+		"no compaction":           {from: now.Add(-49 * time.Hour), bucket: "minute"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			query := &CTRStatsQueryDto{From: tc.from, To: tc.from.Add(time.Hour), Bucket: tc.bucket}
+			if _, err := newCTRStatsFilter(query, tc.compaction, now); !errors.Is(err, tc.wantErr) {
+				t.Errorf("newCTRStatsFilter() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
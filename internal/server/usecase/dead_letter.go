@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// DefaultDeadLetterPageSize is the number of dead letters listed when no page size is requested.
+	DefaultDeadLetterPageSize = 50
+	// MaxDeadLetterPageSize is the largest number of dead letters that can be listed at once.
+	MaxDeadLetterPageSize = 1000
+)
+
+// IDeadLetterUseCase is an interface for inspecting and replaying the
+// messages given up on by the AMQP handlers.
+type IDeadLetterUseCase interface {
+	ListDeadLetters(ctx context.Context, queue string, pageSize int) (*DeadLetterListDto, error)
+	GetDeadLetter(ctx context.Context, queue, id string) (*DeadLetterDto, error)
+	ReplayDeadLetters(ctx context.Context, queue string, ids []string) (int, error)
+	PurgeDeadLetters(ctx context.Context, queue string, ids []string) (int, error)
+}
+
+// DeadLetterUseCase is a use case for inspecting and replaying the messages
+// given up on by the AMQP handlers.
+type DeadLetterUseCase struct {
+	deadLetterRepository domain.IDeadLetterRepository
+}
+
+// NewDeadLetterUseCase creates a new instance of DeadLetterUseCase with the given repository.
+func NewDeadLetterUseCase(deadLetterRepository domain.IDeadLetterRepository) *DeadLetterUseCase {
+	return &DeadLetterUseCase{
+		deadLetterRepository: deadLetterRepository,
+	}
+}
+
+type DeadLetterDto struct {
+	ID             string
+	Queue          string
+	Reason         string
+	DeadLetteredAt time.Time
+	Retries        int
+	ContentType    string
+	Body           []byte
+}
+
+type DeadLetterListDto struct {
+	DeadLetters []*DeadLetterDto
+	// Total is the number of messages in the dead-letter queue, including the
+	// ones that were not listed.
+	Total int
+}
+
+// ListDeadLetters returns the oldest messages of the dead-letter queue of
+// queue. pageSize defaults to DefaultDeadLetterPageSize, and ErrInvalidPageSize
+// is returned if it is out of range.
+func (u *DeadLetterUseCase) ListDeadLetters(ctx context.Context, queue string, pageSize int) (*DeadLetterListDto, error) {
+	if pageSize < 0 || pageSize > MaxDeadLetterPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if pageSize == 0 {
+		pageSize = DefaultDeadLetterPageSize
+	}
+
+	deadLetters, total, err := u.deadLetterRepository.ListDeadLetters(ctx, queue, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &DeadLetterListDto{
+		DeadLetters: make([]*DeadLetterDto, len(deadLetters)),
+		Total:       total,
+	}
+	for i, dl := range deadLetters {
+		dto.DeadLetters[i] = newDeadLetterDto(dl)
+	}
+	return dto, nil
+}
+
+// GetDeadLetter returns a message of the dead-letter queue of queue without
+// removing it. It returns domain.ErrDeadLetterNotFound if there is no such message.
+func (u *DeadLetterUseCase) GetDeadLetter(ctx context.Context, queue, id string) (*DeadLetterDto, error) {
+	dl, err := u.deadLetterRepository.GetDeadLetter(ctx, queue, id)
+	if err != nil {
+		return nil, err
+	}
+	return newDeadLetterDto(dl), nil
+}
+
+// ReplayDeadLetters publishes the given messages, or all of them if ids is
+// empty, back to queue and returns how many were replayed.
+func (u *DeadLetterUseCase) ReplayDeadLetters(ctx context.Context, queue string, ids []string) (int, error) {
+	return u.deadLetterRepository.ReplayDeadLetters(ctx, queue, ids)
+}
+
+// PurgeDeadLetters deletes the given messages, or all of them if ids is
+// empty, and returns how many were deleted.
+func (u *DeadLetterUseCase) PurgeDeadLetters(ctx context.Context, queue string, ids []string) (int, error) {
+	return u.deadLetterRepository.PurgeDeadLetters(ctx, queue, ids)
+}
+
+func newDeadLetterDto(dl *domain.DeadLetter) *DeadLetterDto {
+	return &DeadLetterDto{
+		ID:             dl.ID,
+		Queue:          dl.Queue,
+		Reason:         dl.Reason,
+		DeadLetteredAt: dl.DeadLetteredAt,
+		Retries:        dl.Retries,
+		ContentType:    dl.ContentType,
+		Body:           dl.Body,
+	}
+}
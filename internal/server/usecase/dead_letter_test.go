@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestListDeadLetters(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		pageSize int
+		mockFunc func(*domain.MockIDeadLetterRepository)
+		want     *DeadLetterListDto
+		wantErr  error
+	}{
+		"default page size": {
+			mockFunc: func(m *domain.MockIDeadLetterRepository) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "ctr_logs", DefaultDeadLetterPageSize).Return([]*domain.DeadLetter{
+					{ID: "msg-1", Queue: "ctr_logs", Reason: "invalid CTR log request", Body: []byte(`{}`)},
+				}, 3, nil).Times(1)
+			},
+			want: &DeadLetterListDto{
+				DeadLetters: []*DeadLetterDto{{ID: "msg-1", Queue: "ctr_logs", Reason: "invalid CTR log request", Body: []byte(`{}`)}},
+				Total:       3,
+			},
+		},
+		"page size out of range": {
+			pageSize: MaxDeadLetterPageSize + 1,
+			mockFunc: func(m *domain.MockIDeadLetterRepository) {},
+			wantErr:  ErrInvalidPageSize,
+		},
+		"unknown queue": {
+			pageSize: 10,
+			mockFunc: func(m *domain.MockIDeadLetterRepository) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), "ctr_logs", 10).Return(nil, 0, domain.ErrUnknownQueue).Times(1)
+			},
+			wantErr: domain.ErrUnknownQueue,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockIDeadLetterRepository(ctrl)
+			tc.mockFunc(mockRepo)
+
+			got, err := NewDeadLetterUseCase(mockRepo).ListDeadLetters(context.Background(), "ctr_logs", tc.pageSize)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ListDeadLetters() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ListDeadLetters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetDeadLetter(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockIDeadLetterRepository(ctrl)
+	mockRepo.EXPECT().GetDeadLetter(gomock.Any(), "ctr_logs", "missing").Return(nil, domain.ErrDeadLetterNotFound).Times(1)
+
+	if _, err := NewDeadLetterUseCase(mockRepo).GetDeadLetter(context.Background(), "ctr_logs", "missing"); !errors.Is(err, domain.ErrDeadLetterNotFound) {
+		t.Errorf("GetDeadLetter() error = %v, want %v", err, domain.ErrDeadLetterNotFound)
+	}
+}
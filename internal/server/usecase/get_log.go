@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+// IGetLogUseCase is an interface for retrieving a single log entry.
+type IGetLogUseCase interface {
+	GetLog(ctx context.Context, id string) (*GetLogDto, error)
+}
+
+// GetLogUseCase is a use case for retrieving a single log entry by its ID.
+type GetLogUseCase struct {
+	logRepository domain.ILogRepository
+}
+
+// NewGetLogUseCase creates a new instance of GetLogUseCase with the given log repository.
+func NewGetLogUseCase(logRepository domain.ILogRepository) *GetLogUseCase {
+	return &GetLogUseCase{
+		logRepository: logRepository,
+	}
+}
+
+// GetLogDto is a data transfer object for a retrieved log entry.
+type GetLogDto struct {
+	ID                 string
+	LogLevel           string
+	Date               time.Time
+	DestinationService string
+	SourceService      string
+	RequestType        string
+	Content            string
+	Attributes         map[string]any
+	TraceID            string
+	SpanID             string
+	ParentSpanID       string
+}
+
+// GetLog retrieves the log entry with the given ID.
+// It returns domain.ErrLogNotFound if no such entry exists.
+func (u *GetLogUseCase) GetLog(ctx context.Context, id string) (*GetLogDto, error) {
+	log, err := u.logRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetLogDto{
+		ID:                 log.ID,
+		LogLevel:           log.LogLevel,
+		Date:               log.Date,
+		DestinationService: log.DestinationService,
+		SourceService:      log.SourceService,
+		RequestType:        log.RequestType,
+		Content:            log.Content,
+		Attributes:         log.Attributes,
+		TraceID:            log.TraceID,
+		SpanID:             log.SpanID,
+		ParentSpanID:       log.ParentSpanID,
+	}, nil
+}
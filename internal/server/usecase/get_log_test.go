@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestGetLog(t *testing.T) {
+	t.Parallel()
+	currTime := time.Now()
+
+	testCases := map[string]struct {
+		mockFunc  func(*domain.MockILogRepository)
+		wantErr   error
+		wantDtoID string
+	}{
+		"GetLog success": {
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().Get(gomock.Any(), "log-id").Return(&domain.Log{
+					ID:                 "log-id",
+					LogLevel:           "INFO",
+					Date:               currTime,
+					DestinationService: "UserService",
+					SourceService:      "AuthService",
+					RequestType:        "POST",
+					Content:            "User created successfully.",
+				}, nil).Times(1)
+			},
+			wantDtoID: "log-id",
+		},
+		"GetLog not found": {
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().Get(gomock.Any(), "log-id").Return(nil, domain.ErrLogNotFound).Times(1)
+			},
+			wantErr: domain.ErrLogNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			getLogUseCase := NewGetLogUseCase(mockRepo)
+			tc.mockFunc(mockRepo)
+
+			result, err := getLogUseCase.GetLog(context.Background(), "log-id")
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("GetLog() expected error %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLog() unexpected error = %v", err)
+			}
+			if result.ID != tc.wantDtoID {
+				t.Errorf("GetLog() expected ID %s, got %s", tc.wantDtoID, result.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"log_service/internal/server/domain"
+)
+
+// MaxTraceLogs is the largest number of logs returned for a single trace.
+const MaxTraceLogs = 10000
+
+// IGetTraceUseCase is an interface for retrieving the logs of a distributed trace.
+type IGetTraceUseCase interface {
+	GetTrace(ctx context.Context, traceID string) ([]*ListLogDto, error)
+}
+
+// GetTraceUseCase is a use case for retrieving the logs of a distributed trace.
+type GetTraceUseCase struct {
+	logRepository domain.ILogRepository
+}
+
+// NewGetTraceUseCase creates a new instance of GetTraceUseCase with the given log repository.
+func NewGetTraceUseCase(logRepository domain.ILogRepository) *GetTraceUseCase {
+	return &GetTraceUseCase{
+		logRepository: logRepository,
+	}
+}
+
+// GetTrace returns the first MaxTraceLogs logs of the trace, oldest first.
+// It returns domain.ErrInvalidTraceID if traceID is malformed and
+// domain.ErrTraceNotFound if no log belongs to the trace.
+func (u *GetTraceUseCase) GetTrace(ctx context.Context, traceID string) ([]*ListLogDto, error) {
+	if err := domain.ValidateTraceID(traceID); err != nil {
+		return nil, err
+	}
+
+	logs, err := u.logRepository.ListTrace(ctx, traceID, MaxTraceLogs)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, domain.ErrTraceNotFound
+	}
+
+	logDtos := make([]*ListLogDto, len(logs))
+	for i := range logs {
+		logDtos[i] = newListLogDto(&logs[i])
+	}
+	return logDtos, nil
+}
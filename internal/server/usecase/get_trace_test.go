@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestGetTrace(t *testing.T) {
+	t.Parallel()
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	currTime := time.Now()
+
+	testCases := map[string]struct {
+		traceID     string
+		mockFunc    func(*domain.MockILogRepository)
+		wantErr     error
+		wantSpanIDs []string
+	}{
+		"GetTrace success": {
+			traceID: traceID,
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ListTrace(gomock.Any(), traceID, MaxTraceLogs).Return([]domain.Log{
+					{ID: "log-1", Date: currTime, TraceID: traceID, SpanID: "00f067aa0ba902b7"},
+					{ID: "log-2", Date: currTime.Add(time.Second), TraceID: traceID, SpanID: "b7ad6b7169203331", ParentSpanID: "00f067aa0ba902b7"},
+				}, nil).Times(1)
+			},
+			wantSpanIDs: []string{"00f067aa0ba902b7", "b7ad6b7169203331"},
+		},
+		"GetTrace not found": {
+			traceID: traceID,
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ListTrace(gomock.Any(), traceID, MaxTraceLogs).Return(nil, nil).Times(1)
+			},
+			wantErr: domain.ErrTraceNotFound,
+		},
+		"GetTrace invalid trace id": {
+			traceID:  "not-a-trace-id",
+			mockFunc: func(m *domain.MockILogRepository) {},
+			wantErr:  domain.ErrInvalidTraceID,
+		},
+		"GetTrace repository error": {
+			traceID: traceID,
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ListTrace(gomock.Any(), traceID, MaxTraceLogs).Return(nil, errors.New("db error")).Times(1)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			getTraceUseCase := NewGetTraceUseCase(mockRepo)
+			tc.mockFunc(mockRepo)
+
+			result, err := getTraceUseCase.GetTrace(context.Background(), tc.traceID)
+
+			if tc.wantErr != nil {
+				if err == nil || (!errors.Is(err, tc.wantErr) && err.Error() != tc.wantErr.Error()) {
+					t.Errorf("GetTrace() expected error %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTrace() unexpected error: %v", err)
+			}
+			if len(result) != len(tc.wantSpanIDs) {
+				t.Fatalf("GetTrace() returned %d logs, want %d", len(result), len(tc.wantSpanIDs))
+			}
+			for i, dto := range result {
+				if dto.TraceID != traceID || dto.SpanID != tc.wantSpanIDs[i] {
+					t.Errorf("GetTrace()[%d] = %s/%s, want %s/%s", i, dto.TraceID, dto.SpanID, traceID, tc.wantSpanIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -2,13 +2,14 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"log_service/internal/server/domain"
 )
 
 type IInsertLogUseCase interface {
-	InsertLog(ctx context.Context, dto *InsertLogDto) error
+	InsertLog(ctx context.Context, dto *InsertLogDto) (string, error)
 }
 
 // IInsertCTRLogUseCase is an interface for inserting CTR logs.
@@ -18,6 +19,7 @@ type IInsertCTRLogUseCase interface {
 
 type InsertLogUseCase struct {
 	logRepository domain.ILogRepository
+	hub           *LogHub
 }
 
 // InsertLogUseCase is a use case for inserting log entries into the database.
@@ -25,9 +27,12 @@ type InsertCTRLogUseCase struct {
 	logRepository domain.ILogRepository
 }
 
-func NewInsertLogUseCase(logRepository domain.ILogRepository) *InsertLogUseCase {
+// NewInsertLogUseCase creates a new instance of InsertLogUseCase storing logs
+// in the given log repository and publishing them to hub once stored.
+func NewInsertLogUseCase(logRepository domain.ILogRepository, hub *LogHub) *InsertLogUseCase {
 	return &InsertLogUseCase{
 		logRepository: logRepository,
+		hub:           hub,
 	}
 }
 
@@ -39,12 +44,20 @@ func NewInsertCTRLogUseCase(logRepository domain.ILogRepository) *InsertCTRLogUs
 }
 
 type InsertLogDto struct {
+	// LogLevel is any alias of a domain.Severity, such as "warning". It is
+	// stored under the canonical name of the severity and defaults to "INFO".
 	LogLevel           string
 	Date               time.Time
 	DestinationService string
 	SourceService      string
 	RequestType        string
 	Content            string
+	Attributes         map[string]any
+	// TraceID, SpanID and ParentSpanID place the log in a distributed trace.
+	// They are optional, but SpanID and ParentSpanID require TraceID.
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
 }
 
 // InsertCTRLogDto is a data transfer object for inserting CTR logs.
@@ -54,23 +67,79 @@ type InsertCTRLogDto struct {
 	ObjectID  string
 }
 
-func (u *InsertLogUseCase) InsertLog(ctx context.Context, dto *InsertLogDto) error {
+// InsertLog assigns a new ID to the log entry, stores it and publishes it to
+// the live subscribers. It returns the ID of the stored entry.
+//
+// It returns domain.ErrInvalidSeverity if the level of the entry is unknown,
+// and domain.ErrInvalidTraceID or domain.ErrInvalidSpanID if its trace
+// context is malformed.
+func (u *InsertLogUseCase) InsertLog(ctx context.Context, dto *InsertLogDto) (string, error) {
+	severity := domain.SeverityInfo
+	if dto.LogLevel != "" {
+		var err error
+		if severity, err = domain.ParseSeverity(dto.LogLevel); err != nil {
+			return "", fmt.Errorf("%w: %q", err, dto.LogLevel)
+		}
+	}
+	if err := validateTraceContext(dto); err != nil {
+		return "", err
+	}
+	id, err := domain.NewLogID()
+	if err != nil {
+		return "", err
+	}
 	log := domain.NewLog(
-		dto.LogLevel,
+		id,
+		severity.String(),
 		dto.Date,
 		dto.DestinationService,
 		dto.SourceService,
 		dto.RequestType,
 		dto.Content,
+		dto.Attributes,
+		dto.TraceID,
+		dto.SpanID,
+		dto.ParentSpanID,
 	)
-	return u.logRepository.Save(ctx, log)
+	if err := u.logRepository.Save(ctx, log); err != nil {
+		return "", err
+	}
+	u.hub.Publish(log)
+	return id, nil
+}
+
+// validateTraceContext checks the trace context of a log entry, if any.
+func validateTraceContext(dto *InsertLogDto) error {
+	if dto.TraceID == "" {
+		if dto.SpanID != "" || dto.ParentSpanID != "" {
+			return fmt.Errorf("%w: span ID without trace ID", domain.ErrInvalidTraceID)
+		}
+		return nil
+	}
+	if err := domain.ValidateTraceID(dto.TraceID); err != nil {
+		return fmt.Errorf("%w: %q", err, dto.TraceID)
+	}
+	for _, spanID := range []string{dto.SpanID, dto.ParentSpanID} {
+		if spanID == "" {
+			continue
+		}
+		if err := domain.ValidateSpanID(spanID); err != nil {
+			return fmt.Errorf("%w: %q", err, spanID)
+		}
+	}
+	return nil
 }
 
 // InsertCTRLog inserts a new CTR log entry into the database.
 // It takes a context and a CTRLogDto object as arguments.
 // It returns an error if the operation fails.
 func (u *InsertCTRLogUseCase) InsertCTRLog(ctx context.Context, dto *InsertCTRLogDto) error {
+	id, err := domain.NewLogID()
+	if err != nil {
+		return err
+	}
 	ctrLog := domain.NewCTRLog(
+		id,
 		dto.EventType,
 		dto.CreatedAt,
 		dto.ObjectID,
@@ -41,11 +41,12 @@ func (m *MockIInsertLogUseCase) EXPECT() *MockIInsertLogUseCaseMockRecorder {
 }
 
 // InsertLog mocks base method.
-func (m *MockIInsertLogUseCase) InsertLog(ctx context.Context, dto *InsertLogDto) error {
+func (m *MockIInsertLogUseCase) InsertLog(ctx context.Context, dto *InsertLogDto) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertLog", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // InsertLog indicates an expected call of InsertLog.
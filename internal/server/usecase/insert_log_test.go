@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -28,18 +30,20 @@ func TestInsertLog(t *testing.T) {
 				SourceService:      "AuthService",
 				RequestType:        "POST",
 				Content:            "User created successfully.",
+				Attributes:         map[string]any{"user_id": "u-1"},
 			},
 			mockFunc: func(m *domain.MockILogRepository) {
 				m.EXPECT().Save(
 					gomock.Any(),
-					&domain.Log{
+					withoutID(&domain.Log{
 						LogLevel:           "INFO",
 						Date:               currTime,
 						DestinationService: "UserService",
 						SourceService:      "AuthService",
 						RequestType:        "POST",
 						Content:            "User created successfully.",
-					},
+						Attributes:         map[string]any{"user_id": "u-1"},
+					}),
 				).Return(nil)
 			},
 		},
@@ -50,18 +54,52 @@ func TestInsertLog(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 			mockUserRepo := domain.NewMockILogRepository(ctrl)
-			logInsertUseCase := NewInsertLogUseCase(mockUserRepo)
+			logInsertUseCase := NewInsertLogUseCase(mockUserRepo, NewLogHub())
 			ctx := context.Background()
 			tt.mockFunc(mockUserRepo)
-			err := logInsertUseCase.InsertLog(ctx, tt.dto)
+			id, err := logInsertUseCase.InsertLog(ctx, tt.dto)
 			if err != nil {
 				t.Errorf("InsertLog() error = %v", err)
 			}
+			if id == "" {
+				t.Errorf("InsertLog() returned an empty ID")
+			}
 		})
 	}
 
 }
 
+func TestInsertLogPublish(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	hub := NewLogHub()
+	sub := hub.Subscribe(domain.LogFilter{}, 10)
+	defer sub.Close()
+	logInsertUseCase := NewInsertLogUseCase(mockRepo, hub)
+
+	// A log that failed to be stored is not published.
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errors.New("failed to save log")).Times(1)
+	if _, err := logInsertUseCase.InsertLog(context.Background(), &InsertLogDto{Content: "lost"}); err == nil {
+		t.Fatalf("InsertLog() expected error but got none")
+	}
+
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	id, err := logInsertUseCase.InsertLog(context.Background(), &InsertLogDto{Content: "stored"})
+	if err != nil {
+		t.Fatalf("InsertLog() unexpected error = %v", err)
+	}
+
+	select {
+	case log := <-sub.Logs():
+		if log.ID != id || log.Content != "stored" {
+			t.Errorf("InsertLog() published unexpected log %+v", log)
+		}
+	default:
+		t.Fatalf("InsertLog() did not publish the stored log")
+	}
+}
+
 func TestInsertCTRLog(t *testing.T) {
 	t.Parallel()
 
@@ -82,11 +120,13 @@ func TestInsertCTRLog(t *testing.T) {
 			mockFunc: func(m *domain.MockILogRepository) {
 				m.EXPECT().CTRSave(
 					gomock.Any(),
-					&domain.CTRLog{
-						EventType: "tap",
-						CreatedAt: currTime,
-						ObjectID:  "123",
-					},
+					gomock.Cond(func(x any) bool {
+						ctrLog, ok := x.(*domain.CTRLog)
+						return ok && ctrLog.ID != "" &&
+							ctrLog.EventType == "tap" &&
+							ctrLog.CreatedAt.Equal(currTime) &&
+							ctrLog.ObjectID == "123"
+					}),
 				).Return(nil)
 			},
 		},
@@ -107,3 +147,110 @@ func TestInsertCTRLog(t *testing.T) {
 		})
 	}
 }
+
+// withoutID matches a *domain.Log that equals want apart from its ID,
+// which must be set.
+func withoutID(want *domain.Log) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		got, ok := x.(*domain.Log)
+		if !ok || got.ID == "" {
+			return false
+		}
+		withID := *want
+		withID.ID = got.ID
+		return reflect.DeepEqual(&withID, got)
+	})
+}
+
+func TestInsertLogTraceContext(t *testing.T) {
+	t.Parallel()
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tests := map[string]struct {
+		dto     *InsertLogDto
+		wantErr error
+	}{
+		"valid": {
+			dto: &InsertLogDto{TraceID: traceID, SpanID: spanID, ParentSpanID: "b7ad6b7169203331"},
+		},
+		"trace only": {
+			dto: &InsertLogDto{TraceID: traceID},
+		},
+		"invalid trace ID": {
+			dto:     &InsertLogDto{TraceID: "4BF92F3577B34DA6A3CE929D0E0E4736"},
+			wantErr: domain.ErrInvalidTraceID,
+		},
+		"invalid span ID": {
+			dto:     &InsertLogDto{TraceID: traceID, SpanID: "0000000000000000"},
+			wantErr: domain.ErrInvalidSpanID,
+		},
+		"invalid parent span ID": {
+			dto:     &InsertLogDto{TraceID: traceID, ParentSpanID: "xyz"},
+			wantErr: domain.ErrInvalidSpanID,
+		},
+		"span ID without trace ID": {
+			dto:     &InsertLogDto{SpanID: spanID},
+			wantErr: domain.ErrInvalidTraceID,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			logInsertUseCase := NewInsertLogUseCase(mockRepo, NewLogHub())
+
+			_, err := logInsertUseCase.InsertLog(context.Background(), tt.dto)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InsertLog() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInsertLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		level     string
+		wantLevel string
+		wantErr   error
+	}{
+		"canonical":     {level: "WARN", wantLevel: "WARN"},
+		"alias":         {level: "warning", wantLevel: "WARN"},
+		"lower case":    {level: "debug", wantLevel: "DEBUG"},
+		"syslog level":  {level: "crit", wantLevel: "FATAL"},
+		"default level": {level: "", wantLevel: "INFO"},
+		"unknown level": {level: "verbose", wantErr: domain.ErrInvalidSeverity},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.Log) error {
+						if log.LogLevel != tt.wantLevel {
+							t.Errorf("Save() got level %q, want %q", log.LogLevel, tt.wantLevel)
+						}
+						return nil
+					}).Times(1)
+			}
+			logInsertUseCase := NewInsertLogUseCase(mockRepo, NewLogHub())
+
+			_, err := logInsertUseCase.InsertLog(context.Background(), &InsertLogDto{LogLevel: tt.level})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InsertLog() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
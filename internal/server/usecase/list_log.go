@@ -2,13 +2,34 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"log_service/internal/server/domain"
 )
 
+const (
+	// DefaultPageSize is the number of logs returned when no page size is requested.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page size that can be requested.
+	MaxPageSize = 1000
+)
+
+var (
+	// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+	// or was issued for a different sort order.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidPageSize is returned when the requested page size is out of range.
+	ErrInvalidPageSize = errors.New("invalid page size")
+	// ErrInvalidSortOrder is returned when the requested sort order is unknown.
+	ErrInvalidSortOrder = errors.New("invalid sort order")
+)
+
 type IListLogsUseCase interface {
-	ListLogs(ctx context.Context) ([]*ListLogDto, error)
+	ListLogs(ctx context.Context, query *ListLogsQueryDto) ([]*ListLogDto, string, error)
 }
 
 type ListLogsUseCase struct {
@@ -21,34 +42,180 @@ func NewListLogsUseCase(logRepository domain.ILogRepository) *ListLogsUseCase {
 	}
 }
 
-// TODO: [Server] Implement LogID Assignment for Logs
-// https://github.com/okuda-seminar/log_service/issues/34#issue-2601726013
+// ListLogsQueryDto is a data transfer object describing which logs to list.
+//
+// Zero-valued fields do not restrict the result. Order defaults to "desc"
+// and PageSize defaults to DefaultPageSize.
+type ListLogsQueryDto struct {
+	// LogLevel restricts the result to the logs of this severity. Like
+	// MinLogLevel, it accepts any alias of a domain.Severity.
+	LogLevel string
+	// MinLogLevel restricts the result to the logs of this severity or a more severe one.
+	MinLogLevel        string
+	SourceService      string
+	DestinationService string
+	RequestType        string
+	From               time.Time
+	To                 time.Time
+	Content            string
+	// Attributes restricts the result to the logs having all of these attributes.
+	Attributes map[string]any
+	Order      string
+	PageSize   int
+	// Cursor is the opaque cursor returned with the previous page.
+	Cursor string
+}
+
 type ListLogDto struct {
+	ID                 string
 	LogLevel           string
 	Date               time.Time
 	DestinationService string
 	SourceService      string
 	RequestType        string
 	Content            string
+	Attributes         map[string]any
+	TraceID            string
+	SpanID             string
+	ParentSpanID       string
 }
 
-func (u *ListLogsUseCase) ListLogs(ctx context.Context) ([]*ListLogDto, error) {
-	logs, err := u.logRepository.List(ctx)
+// listCursor is the decoded form of the opaque pagination cursor.
+type listCursor struct {
+	Order domain.SortOrder `json:"o"`
+	Date  time.Time        `json:"d"`
+	ID    string           `json:"i"`
+}
+
+// ListLogs returns one page of logs matching the query, along with the
+// cursor of the next page. The cursor is empty when there are no more logs.
+func (u *ListLogsUseCase) ListLogs(ctx context.Context, query *ListLogsQueryDto) ([]*ListLogDto, string, error) {
+	filter, err := newLogFilter(query)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// Fetch one extra log to know whether there is a next page.
+	pageSize := filter.Limit
+	filter.Limit++
+	logs, err := u.logRepository.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(logs) > pageSize {
+		logs = logs[:pageSize]
+		last := logs[len(logs)-1]
+		nextCursor, err = encodeCursor(listCursor{Order: filter.Order, Date: last.Date, ID: last.ID})
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	var logDtos []*ListLogDto
 	for _, log := range logs {
-		logDto := &ListLogDto{
-			LogLevel:           log.LogLevel,
-			Date:               log.Date,
-			DestinationService: log.DestinationService,
-			SourceService:      log.SourceService,
-			RequestType:        log.RequestType,
-			Content:            log.Content,
+		logDtos = append(logDtos, newListLogDto(&log))
+	}
+	return logDtos, nextCursor, nil
+}
+
+func newListLogDto(log *domain.Log) *ListLogDto {
+	return &ListLogDto{
+		ID:                 log.ID,
+		LogLevel:           log.LogLevel,
+		Date:               log.Date,
+		DestinationService: log.DestinationService,
+		SourceService:      log.SourceService,
+		RequestType:        log.RequestType,
+		Content:            log.Content,
+		Attributes:         log.Attributes,
+		TraceID:            log.TraceID,
+		SpanID:             log.SpanID,
+		ParentSpanID:       log.ParentSpanID,
+	}
+}
+
+// newLogFilter validates the query and converts it into a domain.LogFilter.
+func newLogFilter(query *ListLogsQueryDto) (*domain.LogFilter, error) {
+	severity, minSeverity, err := parseSeverityFilters(query)
+	if err != nil {
+		return nil, err
+	}
+	filter := &domain.LogFilter{
+		Severity:           severity,
+		MinSeverity:        minSeverity,
+		SourceService:      query.SourceService,
+		DestinationService: query.DestinationService,
+		RequestType:        query.RequestType,
+		From:               query.From,
+		To:                 query.To,
+		Content:            query.Content,
+		Attributes:         query.Attributes,
+		Order:              domain.SortOrderDesc,
+		Limit:              DefaultPageSize,
+	}
+
+	switch domain.SortOrder(query.Order) {
+	case "":
+	case domain.SortOrderAsc, domain.SortOrderDesc:
+		filter.Order = domain.SortOrder(query.Order)
+	default:
+		return nil, ErrInvalidSortOrder
+	}
+
+	if query.PageSize < 0 || query.PageSize > MaxPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if query.PageSize > 0 {
+		filter.Limit = query.PageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil || cursor.Order != filter.Order {
+			return nil, ErrInvalidCursor
+		}
+		filter.After = &domain.LogCursor{Date: cursor.Date, ID: cursor.ID}
+	}
+	return filter, nil
+}
+
+// parseSeverityFilters parses the exact and minimum levels of the query.
+// Empty levels yield domain.SeverityUnspecified, which does not restrict the result.
+func parseSeverityFilters(query *ListLogsQueryDto) (severity, minSeverity domain.Severity, err error) {
+	if query.LogLevel != "" {
+		if severity, err = domain.ParseSeverity(query.LogLevel); err != nil {
+			return 0, 0, fmt.Errorf("%w: %q", err, query.LogLevel)
 		}
-		logDtos = append(logDtos, logDto)
 	}
-	return logDtos, nil
+	if query.MinLogLevel != "" {
+		if minSeverity, err = domain.ParseSeverity(query.MinLogLevel); err != nil {
+			return 0, 0, fmt.Errorf("%w: %q", err, query.MinLogLevel)
+		}
+	}
+	return severity, minSeverity, nil
+}
+
+func encodeCursor(cursor listCursor) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var cursor listCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.ID == "" {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
 }
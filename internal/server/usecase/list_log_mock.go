@@ -35,16 +35,17 @@ func (m *MockIListLogsUseCase) EXPECT() *MockIListLogsUseCaseMockRecorder {
 }
 
 // ListLogs mocks base method.
-func (m *MockIListLogsUseCase) ListLogs(ctx context.Context) ([]*ListLogDto, error) {
+func (m *MockIListLogsUseCase) ListLogs(ctx context.Context, query *ListLogsQueryDto) ([]*ListLogDto, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLogs", ctx)
+	ret := m.ctrl.Call(m, "ListLogs", ctx, query)
 	ret0, _ := ret[0].([]*ListLogDto)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ListLogs indicates an expected call of ListLogs.
-func (mr *MockIListLogsUseCaseMockRecorder) ListLogs(ctx interface{}) *gomock.Call {
+func (mr *MockIListLogsUseCaseMockRecorder) ListLogs(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogs", reflect.TypeOf((*MockIListLogsUseCase)(nil).ListLogs), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogs", reflect.TypeOf((*MockIListLogsUseCase)(nil).ListLogs), ctx, query)
 }
@@ -30,14 +30,14 @@ func TestListLog(t *testing.T) {
 					RequestType:        "POST",
 					Content:            "User created successfully.",
 				}
-				m.EXPECT().List(gomock.Any()).Return([]domain.Log{*sampleLog}, nil).Times(1)
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return([]domain.Log{*sampleLog}, nil).Times(1)
 			},
 			wantLogs:  1,
 			wantError: false,
 		},
 		"ListLogs failure": {
 			mockFunc: func(m *domain.MockILogRepository) {
-				m.EXPECT().List(gomock.Any()).Return(nil, errors.New("failed to list logs")).Times(1)
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to list logs")).Times(1)
 			},
 			wantLogs:  0,
 			wantError: true,
@@ -53,7 +53,7 @@ func TestListLog(t *testing.T) {
 			ctx := context.Background()
 			tc.mockFunc(mockRepo)
 
-			results, err := logListUseCase.ListLogs(ctx)
+			results, _, err := logListUseCase.ListLogs(ctx, &ListLogsQueryDto{})
 
 			if tc.wantError {
 				if err == nil {
@@ -70,3 +70,136 @@ func TestListLog(t *testing.T) {
 		})
 	}
 }
+
+func TestListLogPagination(t *testing.T) {
+	t.Parallel()
+	currTime := time.Now()
+	logs := []domain.Log{
+		{ID: "log-1", LogLevel: "INFO", Date: currTime},
+		{ID: "log-2", LogLevel: "INFO", Date: currTime.Add(time.Second)},
+		{ID: "log-3", LogLevel: "INFO", Date: currTime.Add(2 * time.Second)},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	logListUseCase := NewListLogsUseCase(mockRepo)
+
+	// The first page asks for one extra log to detect the next page.
+	mockRepo.EXPECT().List(gomock.Any(), &domain.LogFilter{
+		Severity:   domain.SeverityInfo,
+		Attributes: map[string]any{"user_id": "u-1"},
+		Order:      domain.SortOrderAsc,
+		Limit:      3,
+	}).Return(logs, nil).Times(1)
+
+	page, nextCursor, err := logListUseCase.ListLogs(context.Background(), &ListLogsQueryDto{
+		LogLevel:   "info",
+		Attributes: map[string]any{"user_id": "u-1"},
+		Order:      "asc",
+		PageSize:   2,
+	})
+	if err != nil {
+		t.Fatalf("ListLogs() unexpected error = %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("ListLogs() expected 2 logs, got %d", len(page))
+	}
+	if nextCursor == "" {
+		t.Fatalf("ListLogs() expected a next cursor")
+	}
+
+	// The second page resumes after the last log of the first page.
+	mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter *domain.LogFilter) ([]domain.Log, error) {
+			if filter.After == nil || filter.After.ID != "log-2" || !filter.After.Date.Equal(logs[1].Date) {
+				t.Errorf("List() called with unexpected cursor %+v", filter.After)
+			}
+			return logs[2:], nil
+		}).Times(1)
+
+	page, nextCursor, err = logListUseCase.ListLogs(context.Background(), &ListLogsQueryDto{
+		LogLevel: "INFO",
+		Order:    "asc",
+		PageSize: 2,
+		Cursor:   nextCursor,
+	})
+	if err != nil {
+		t.Fatalf("ListLogs() unexpected error = %v", err)
+	}
+	if len(page) != 1 || page[0].ID != "log-3" {
+		t.Errorf("ListLogs() unexpected second page %+v", page)
+	}
+	if nextCursor != "" {
+		t.Errorf("ListLogs() expected no next cursor, got %s", nextCursor)
+	}
+}
+
+func TestListLogMinLevel(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockILogRepository(ctrl)
+	logListUseCase := NewListLogsUseCase(mockRepo)
+
+	mockRepo.EXPECT().List(gomock.Any(), &domain.LogFilter{
+		MinSeverity: domain.SeverityWarn,
+		Order:       domain.SortOrderDesc,
+		Limit:       DefaultPageSize + 1,
+	}).Return(nil, nil).Times(1)
+
+	if _, _, err := logListUseCase.ListLogs(context.Background(), &ListLogsQueryDto{MinLogLevel: "Warning"}); err != nil {
+		t.Fatalf("ListLogs() unexpected error = %v", err)
+	}
+}
+
+func TestListLogInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	descCursor, err := encodeCursor(listCursor{Order: domain.SortOrderDesc, Date: time.Now(), ID: "log-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		query   *ListLogsQueryDto
+		wantErr error
+	}{
+		"unknown order": {
+			query:   &ListLogsQueryDto{Order: "random"},
+			wantErr: ErrInvalidSortOrder,
+		},
+		"page size too large": {
+			query:   &ListLogsQueryDto{PageSize: MaxPageSize + 1},
+			wantErr: ErrInvalidPageSize,
+		},
+		"malformed cursor": {
+			query:   &ListLogsQueryDto{Cursor: "not a cursor"},
+			wantErr: ErrInvalidCursor,
+		},
+		"cursor for another order": {
+			query:   &ListLogsQueryDto{Order: "asc", Cursor: descCursor},
+			wantErr: ErrInvalidCursor,
+		},
+		"unknown level": {
+			query:   &ListLogsQueryDto{LogLevel: "verbose"},
+			wantErr: domain.ErrInvalidSeverity,
+		},
+		"unknown min level": {
+			query:   &ListLogsQueryDto{MinLogLevel: "loud"},
+			wantErr: domain.ErrInvalidSeverity,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			logListUseCase := NewListLogsUseCase(mockRepo)
+
+			_, _, err := logListUseCase.ListLogs(context.Background(), tc.query)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("ListLogs() expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
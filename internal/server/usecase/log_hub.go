@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"log_service/internal/server/domain"
+)
+
+// DefaultLogSubscriptionBuffer is the number of logs a subscriber may lag
+// behind before logs are dropped for it.
+const DefaultLogSubscriptionBuffer = 256
+
+// LogHub broadcasts newly stored logs to the subscribers whose filter they match.
+//
+// Publishing never blocks: when a subscriber does not keep up and its buffer
+// is full, the log is dropped for that subscriber only and counted, so that
+// slow subscribers cannot hold up ingestion.
+type LogHub struct {
+	mu     sync.RWMutex
+	subs   map[*LogSubscription]struct{}
+	closed bool
+}
+
+// NewLogHub creates a new LogHub without subscribers.
+func NewLogHub() *LogHub {
+	return &LogHub{
+		subs: make(map[*LogSubscription]struct{}),
+	}
+}
+
+// LogSubscription receives the logs published to a LogHub that match its filter.
+type LogSubscription struct {
+	hub     *LogHub
+	filter  domain.LogFilter
+	logs    chan *ListLogDto
+	dropped atomic.Uint64
+}
+
+// Subscribe registers a subscriber for the logs matching filter, buffering at
+// most buffer logs. The subscription must be closed once it is no longer used.
+// If the hub is closed, the returned subscription is already closed.
+func (h *LogHub) Subscribe(filter domain.LogFilter, buffer int) *LogSubscription {
+	sub := &LogSubscription{
+		hub:    h,
+		filter: filter,
+		logs:   make(chan *ListLogDto, buffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.logs)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish sends log to every subscriber whose filter it matches.
+func (h *LogHub) Publish(log *domain.Log) {
+	var dto *ListLogDto
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subs {
+		if !sub.filter.Matches(log) {
+			continue
+		}
+		if dto == nil {
+			dto = newListLogDto(log)
+		}
+		select {
+		case sub.logs <- dto:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// Close closes every subscription and makes later subscriptions closed from
+// the start. It is used to end the streams when the server shuts down.
+func (h *LogHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		close(sub.logs)
+	}
+}
+
+// Logs returns the channel on which the matching logs are delivered.
+// The logs must not be modified. The channel is closed when the subscription
+// or its hub is closed.
+func (s *LogSubscription) Logs() <-chan *ListLogDto {
+	return s.logs
+}
+
+// TakeDropped returns the number of logs dropped because the buffer was full
+// since the previous call.
+func (s *LogSubscription) TakeDropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+// Close unregisters the subscription. It is safe to call it more than once.
+func (s *LogSubscription) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.logs)
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"sync"
+	"testing"
+
+	"log_service/internal/server/domain"
+)
+
+func TestLogHubFilter(t *testing.T) {
+	t.Parallel()
+	hub := NewLogHub()
+	errorLogs := hub.Subscribe(domain.LogFilter{Severity: domain.SeverityError}, 10)
+	defer errorLogs.Close()
+	all := hub.Subscribe(domain.LogFilter{}, 10)
+	defer all.Close()
+
+	hub.Publish(&domain.Log{ID: "log-1", LogLevel: "INFO"})
+	hub.Publish(&domain.Log{ID: "log-2", LogLevel: "ERROR"})
+
+	if got := receiveIDs(all); len(got) != 2 || got[0] != "log-1" || got[1] != "log-2" {
+		t.Errorf("unfiltered subscriber received %v", got)
+	}
+	if got := receiveIDs(errorLogs); len(got) != 1 || got[0] != "log-2" {
+		t.Errorf("filtered subscriber received %v", got)
+	}
+}
+
+func TestLogHubSlowSubscriber(t *testing.T) {
+	t.Parallel()
+	hub := NewLogHub()
+	slow := hub.Subscribe(domain.LogFilter{}, 2)
+	defer slow.Close()
+	fast := hub.Subscribe(domain.LogFilter{}, 10)
+	defer fast.Close()
+
+	for _, id := range []string{"log-1", "log-2", "log-3", "log-4", "log-5"} {
+		hub.Publish(&domain.Log{ID: id})
+	}
+
+	if got := receiveIDs(slow); len(got) != 2 || got[0] != "log-1" || got[1] != "log-2" {
+		t.Errorf("slow subscriber received %v", got)
+	}
+	if dropped := slow.TakeDropped(); dropped != 3 {
+		t.Errorf("slow subscriber dropped %d logs, want 3", dropped)
+	}
+	if dropped := slow.TakeDropped(); dropped != 0 {
+		t.Errorf("TakeDropped() did not reset the count, got %d", dropped)
+	}
+	if got := receiveIDs(fast); len(got) != 5 {
+		t.Errorf("fast subscriber received %v", got)
+	}
+}
+
+func TestLogHubClose(t *testing.T) {
+	t.Parallel()
+	hub := NewLogHub()
+	sub := hub.Subscribe(domain.LogFilter{}, 10)
+	closed := hub.Subscribe(domain.LogFilter{}, 10)
+	closed.Close()
+	closed.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range sub.Logs() {
+		}
+	}()
+
+	hub.Publish(&domain.Log{ID: "log-1"})
+	hub.Close()
+	wg.Wait()
+	sub.Close()
+
+	late := hub.Subscribe(domain.LogFilter{}, 10)
+	if _, ok := <-late.Logs(); ok {
+		t.Errorf("subscription to a closed hub is open")
+	}
+	hub.Publish(&domain.Log{ID: "log-2"})
+}
+
+// receiveIDs returns the IDs of the logs buffered for sub.
+func receiveIDs(sub *LogSubscription) []string {
+	var ids []string
+	for {
+		select {
+		case log := <-sub.Logs():
+			ids = append(ids, log.ID)
+		default:
+			return ids
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"log_service/internal/server/domain"
+)
+
+const (
+	// DefaultServiceGraphRange is the time range covered when no start is requested.
+	DefaultServiceGraphRange = 24 * time.Hour
+	// MaxServiceGraphRange bounds the time range so that a single request
+	// cannot scan the whole log table.
+	MaxServiceGraphRange = 31 * 24 * time.Hour
+)
+
+// IServiceGraphUseCase is an interface for building the dependency graph of the services.
+type IServiceGraphUseCase interface {
+	GetServiceGraph(ctx context.Context, query *ServiceGraphQueryDto) (*ServiceGraphDto, error)
+}
+
+// ServiceGraphUseCase is a use case for building the dependency graph of the
+// services from the source and destination of the stored logs.
+type ServiceGraphUseCase struct {
+	logRepository domain.ILogRepository
+}
+
+// NewServiceGraphUseCase creates a new instance of ServiceGraphUseCase with the given log repository.
+func NewServiceGraphUseCase(logRepository domain.ILogRepository) *ServiceGraphUseCase {
+	return &ServiceGraphUseCase{
+		logRepository: logRepository,
+	}
+}
+
+// ServiceGraphQueryDto is a data transfer object describing the time range of the graph.
+//
+// To defaults to now and From defaults to DefaultServiceGraphRange before To.
+type ServiceGraphQueryDto struct {
+	From time.Time
+	To   time.Time
+}
+
+// ServiceGraphDto is the dependency graph of the services. Nodes are sorted
+// by name and edges by source then destination.
+type ServiceGraphDto struct {
+	Nodes []*ServiceNodeDto
+	Edges []*ServiceEdgeDto
+}
+
+// ServiceNodeDto is a service of the graph with the calls it received and sent.
+type ServiceNodeDto struct {
+	Name      string
+	CallsIn   int64
+	CallsOut  int64
+	ErrorsIn  int64
+	ErrorsOut int64
+}
+
+// ServiceEdgeDto is the calls from one service to another. Every log is
+// counted as one call, and as an error when its level is an error level.
+type ServiceEdgeDto struct {
+	Source      string
+	Destination string
+	Calls       int64
+	Errors      int64
+	// Levels is the number of calls per log level.
+	Levels map[string]int64
+}
+
+// GetServiceGraph returns the services which exchanged logs within the
+// requested time range and the calls between them.
+func (u *ServiceGraphUseCase) GetServiceGraph(ctx context.Context, query *ServiceGraphQueryDto) (*ServiceGraphDto, error) {
+	filter := &domain.ServiceGraphFilter{From: query.From, To: query.To}
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-DefaultServiceGraphRange)
+	}
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > MaxServiceGraphRange {
+		return nil, ErrInvalidTimeRange
+	}
+
+	counts, err := u.logRepository.ServiceCallCounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &ServiceGraphDto{Nodes: []*ServiceNodeDto{}, Edges: []*ServiceEdgeDto{}}
+	nodes := make(map[string]*ServiceNodeDto)
+	node := func(name string) *ServiceNodeDto {
+		n, ok := nodes[name]
+		if !ok {
+			n = &ServiceNodeDto{Name: name}
+			nodes[name] = n
+			graph.Nodes = append(graph.Nodes, n)
+		}
+		return n
+	}
+	type edgeKey struct{ source, destination string }
+	edges := make(map[edgeKey]*ServiceEdgeDto)
+	for _, count := range counts {
+		key := edgeKey{count.SourceService, count.DestinationService}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &ServiceEdgeDto{
+				Source:      count.SourceService,
+				Destination: count.DestinationService,
+				Levels:      make(map[string]int64),
+			}
+			edges[key] = edge
+			graph.Edges = append(graph.Edges, edge)
+		}
+		edge.Calls += count.Count
+		edge.Levels[count.LogLevel] += count.Count
+
+		source, destination := node(count.SourceService), node(count.DestinationService)
+		source.CallsOut += count.Count
+		destination.CallsIn += count.Count
+		if domain.IsErrorLevel(count.LogLevel) {
+			edge.Errors += count.Count
+			source.ErrorsOut += count.Count
+			destination.ErrorsIn += count.Count
+		}
+	}
+
+	slices.SortFunc(graph.Nodes, func(a, b *ServiceNodeDto) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(graph.Edges, func(a, b *ServiceEdgeDto) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.Destination, b.Destination))
+	})
+	return graph, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"log_service/internal/server/domain"
+)
+
+func TestGetServiceGraph(t *testing.T) {
+	t.Parallel()
+	to := time.Date(2024, 9, 23, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-time.Hour)
+
+	testCases := map[string]struct {
+		query     *ServiceGraphQueryDto
+		mockFunc  func(*domain.MockILogRepository)
+		wantErr   error
+		wantGraph *ServiceGraphDto
+	}{
+		"success": {
+			query: &ServiceGraphQueryDto{From: from, To: to},
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ServiceCallCounts(gomock.Any(), &domain.ServiceGraphFilter{From: from, To: to}).Return([]domain.ServiceCallCount{
+					{SourceService: "gateway", DestinationService: "user", LogLevel: "INFO", Count: 8},
+					{SourceService: "auth", DestinationService: "user", LogLevel: "INFO", Count: 3},
+					{SourceService: "gateway", DestinationService: "user", LogLevel: "ERROR", Count: 2},
+					{SourceService: "gateway", DestinationService: "auth", LogLevel: "WARN", Count: 1},
+				}, nil).Times(1)
+			},
+			wantGraph: &ServiceGraphDto{
+				Nodes: []*ServiceNodeDto{
+					{Name: "auth", CallsIn: 1, CallsOut: 3},
+					{Name: "gateway", CallsOut: 11, ErrorsOut: 2},
+					{Name: "user", CallsIn: 13, ErrorsIn: 2},
+				},
+				Edges: []*ServiceEdgeDto{
+					{Source: "auth", Destination: "user", Calls: 3, Levels: map[string]int64{"INFO": 3}},
+					{Source: "gateway", Destination: "auth", Calls: 1, Levels: map[string]int64{"WARN": 1}},
+					{Source: "gateway", Destination: "user", Calls: 10, Errors: 2, Levels: map[string]int64{"INFO": 8, "ERROR": 2}},
+				},
+			},
+		},
+		"empty": {
+			query: &ServiceGraphQueryDto{From: from, To: to},
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ServiceCallCounts(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			},
+			wantGraph: &ServiceGraphDto{Nodes: []*ServiceNodeDto{}, Edges: []*ServiceEdgeDto{}},
+		},
+		"default range": {
+			query: &ServiceGraphQueryDto{To: to},
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ServiceCallCounts(gomock.Any(), &domain.ServiceGraphFilter{From: to.Add(-DefaultServiceGraphRange), To: to}).Return(nil, nil).Times(1)
+			},
+			wantGraph: &ServiceGraphDto{Nodes: []*ServiceNodeDto{}, Edges: []*ServiceEdgeDto{}},
+		},
+		"empty range": {
+			query:    &ServiceGraphQueryDto{From: to, To: to},
+			mockFunc: func(m *domain.MockILogRepository) {},
+			wantErr:  ErrInvalidTimeRange,
+		},
+		"range too long": {
+			query:    &ServiceGraphQueryDto{From: to.Add(-MaxServiceGraphRange - time.Hour), To: to},
+			mockFunc: func(m *domain.MockILogRepository) {},
+			wantErr:  ErrInvalidTimeRange,
+		},
+		"repository error": {
+			query: &ServiceGraphQueryDto{From: from, To: to},
+			mockFunc: func(m *domain.MockILogRepository) {
+				m.EXPECT().ServiceCallCounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error")).Times(1)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockILogRepository(ctrl)
+			tc.mockFunc(mockRepo)
+			serviceGraphUseCase := NewServiceGraphUseCase(mockRepo)
+
+			graph, err := serviceGraphUseCase.GetServiceGraph(context.Background(), tc.query)
+
+			if tc.wantErr != nil {
+				if err == nil || (!errors.Is(err, tc.wantErr) && err.Error() != tc.wantErr.Error()) {
+					t.Errorf("GetServiceGraph() expected error %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetServiceGraph() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantGraph, graph); diff != "" {
+				t.Errorf("GetServiceGraph() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
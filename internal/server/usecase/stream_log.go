@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"log_service/internal/server/domain"
+)
+
+// IStreamLogsUseCase is an interface for following newly stored logs.
+type IStreamLogsUseCase interface {
+	SubscribeLogs(query *ListLogsQueryDto) (*LogSubscription, error)
+}
+
+// StreamLogsUseCase is a use case for following the logs as they are stored.
+type StreamLogsUseCase struct {
+	hub *LogHub
+}
+
+// NewStreamLogsUseCase creates a new instance of StreamLogsUseCase receiving logs from hub.
+func NewStreamLogsUseCase(hub *LogHub) *StreamLogsUseCase {
+	return &StreamLogsUseCase{
+		hub: hub,
+	}
+}
+
+// SubscribeLogs subscribes to the logs stored from now on that match the
+// filters of the query. Order, PageSize and Cursor are ignored.
+// The subscription must be closed once it is no longer used.
+//
+// It returns domain.ErrInvalidSeverity if a level of the query is unknown.
+func (u *StreamLogsUseCase) SubscribeLogs(query *ListLogsQueryDto) (*LogSubscription, error) {
+	severity, minSeverity, err := parseSeverityFilters(query)
+	if err != nil {
+		return nil, err
+	}
+	return u.hub.Subscribe(domain.LogFilter{
+		Severity:           severity,
+		MinSeverity:        minSeverity,
+		SourceService:      query.SourceService,
+		DestinationService: query.DestinationService,
+		RequestType:        query.RequestType,
+		From:               query.From,
+		To:                 query.To,
+		Content:            query.Content,
+		Attributes:         query.Attributes,
+	}, DefaultLogSubscriptionBuffer), nil
+}
@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	clientRabbitmq "log_service/internal/client/infrastructure/rabbitmq"
+	"log_service/internal/server/infrastructure/rabbitmq"
+	serverPresentation "log_service/internal/server/presentation"
+)
+
+const (
+	DefaultBufferSize   = 1024
+	DefaultMaxInFlight  = 64
+	DefaultCloseTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNacked is reported when the broker refuses to take a log.
+	ErrNacked = clientRabbitmq.ErrNacked
+	// ErrUnroutable is reported when the broker has no queue to route a log
+	// to, which happens until the server declared its queues.
+	ErrUnroutable = clientRabbitmq.ErrUnroutable
+)
+
+// AsyncConfig controls how an AsyncWritter buffers and publishes logs.
+// Zero-valued fields take their default.
+type AsyncConfig struct {
+	// BufferSize is the number of logs buffered before Overflow applies.
+	BufferSize int
+	// Overflow is what to do with a log written while the buffer is full.
+	Overflow OverflowPolicy
+	// MaxInFlight is the number of logs published before waiting for the
+	// broker to confirm them.
+	MaxInFlight int
+	// CloseTimeout bounds the time Close waits for the buffered logs to be published.
+	CloseTimeout time.Duration
+	// SourceService is the source service of the logs. It defaults to the
+	// name of the executable.
+	SourceService string
+	// OnError is called from the publishing goroutine for every log that
+	// could not be published. Errors are printed to stderr by default, since
+	// the writer is typically the output of the standard logger.
+	OnError func(error)
+}
+
+// AsyncWritter is an io.Writer publishing every write as a log without
+// waiting for the server to store it.
+//
+// Writes are buffered in memory and published in the background with
+// publisher confirms, so a log is only known to be lost if OnError is called
+// for it. Use Flush to wait for the written logs to reach the broker.
+type AsyncWritter struct {
+	publisher logPublisher
+	cfg       AsyncConfig
+	buf       *logBuffer
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// logPublisher publishes logs in confirm mode.
+type logPublisher interface {
+	Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (confirmation, error)
+}
+
+// confirmation is the outcome of a publishing, as *clientRabbitmq.Confirmation.
+type confirmation interface {
+	Wait(ctx context.Context) error
+}
+
+// confirmingPublisher adapts *clientRabbitmq.Publisher to logPublisher.
+type confirmingPublisher struct {
+	*clientRabbitmq.Publisher
+}
+
+func (p confirmingPublisher) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (confirmation, error) {
+	c, err := p.Publisher.Publish(ctx, exchange, key, msg)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewAsyncWritter puts ch in confirm mode and starts publishing the logs
+// written to the returned writer. ch must not be used for anything else.
+func NewAsyncWritter(ch *amqp.Channel, cfg AsyncConfig) (*AsyncWritter, error) {
+	publisher, err := clientRabbitmq.NewPublisher(ch)
+	if err != nil {
+		return nil, err
+	}
+	return newAsyncWritter(confirmingPublisher{publisher}, cfg), nil
+}
+
+// newAsyncWritter starts publishing the logs written to the returned writer
+// with publisher.
+func newAsyncWritter(publisher logPublisher, cfg AsyncConfig) *AsyncWritter {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = DefaultMaxInFlight
+	}
+	if cfg.CloseTimeout <= 0 {
+		cfg.CloseTimeout = DefaultCloseTimeout
+	}
+	if cfg.SourceService == "" {
+		cfg.SourceService = filepath.Base(os.Args[0])
+	}
+	if cfg.OnError == nil {
+		cfg.OnError = func(err error) {
+			fmt.Fprintf(os.Stderr, "logger: failed to publish log: %v\n", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &AsyncWritter{
+		publisher: publisher,
+		cfg:       cfg,
+		buf:       newLogBuffer(cfg.BufferSize, cfg.Overflow),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go w.publishLoop(ctx)
+	return w
+}
+
+// Write buffers p as the content of a log. It only blocks when the buffer is
+// full and the overflow policy is OverflowBlock.
+func (w *AsyncWritter) Write(p []byte) (n int, err error) {
+	if err := w.WriteLog(serverPresentation.AMQPLogRequest{Content: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteLog buffers req like Write buffers its content.
+func (w *AsyncWritter) WriteLog(req serverPresentation.AMQPLogRequest) error {
+	if req.Date.IsZero() {
+		req.Date = time.Now()
+	}
+	if req.SourceService == "" {
+		req.SourceService = w.cfg.SourceService
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return w.buf.push(body)
+}
+
+// Flush waits until the logs written so far are confirmed by the broker,
+// reported to OnError or dropped.
+func (w *AsyncWritter) Flush(ctx context.Context) error {
+	return w.buf.waitIdle(ctx)
+}
+
+// Dropped returns the number of logs dropped because the buffer was full.
+func (w *AsyncWritter) Dropped() uint64 {
+	return w.buf.droppedCount()
+}
+
+// Close stops accepting writes and waits up to CloseTimeout for the buffered
+// logs to be published. The logs still unpublished after that are reported
+// to OnError. The channel is left open.
+func (w *AsyncWritter) Close() error {
+	w.buf.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.CloseTimeout)
+	defer cancel()
+	err := w.buf.waitIdle(ctx)
+
+	// Abort the publishings still in progress on timeout.
+	w.cancel()
+	<-w.done
+	return err
+}
+
+// publishLoop publishes the buffered logs until the buffer is closed and empty.
+func (w *AsyncWritter) publishLoop(ctx context.Context) {
+	defer close(w.done)
+	for {
+		bodies, ok := w.buf.popBatch(w.cfg.MaxInFlight)
+		if !ok {
+			return
+		}
+		w.publishBatch(ctx, bodies)
+		w.buf.settle(len(bodies))
+	}
+}
+
+// publishBatch publishes bodies, then waits for the broker to confirm them.
+func (w *AsyncWritter) publishBatch(ctx context.Context, bodies [][]byte) {
+	confirmations := make([]confirmation, 0, len(bodies))
+	for _, body := range bodies {
+		confirmation, err := w.publisher.Publish(ctx,
+			"",                      // exchange
+			rabbitmq.LOG_QUEUE_NAME, // routing key
+			amqp.Publishing{
+				ContentType:  "text/plain",
+				DeliveryMode: amqp.Persistent,
+				Body:         body,
+			})
+		if err != nil {
+			w.cfg.OnError(err)
+			continue
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+
+	for _, confirmation := range confirmations {
+		if err := confirmation.Wait(ctx); err != nil {
+			w.cfg.OnError(err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	serverPresentation "log_service/internal/server/presentation"
+)
+
+// fakePublisher records the contents of the published logs, which the broker
+// confirms once confirm is closed.
+type fakePublisher struct {
+	mu       sync.Mutex
+	contents []string
+	// published receives a value for every publishing.
+	published chan struct{}
+	confirm   chan struct{}
+}
+
+func newFakePublisher() *fakePublisher {
+	return &fakePublisher{published: make(chan struct{}, 16), confirm: make(chan struct{})}
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) (confirmation, error) {
+	var req serverPresentation.AMQPLogRequest
+	if err := json.Unmarshal(msg.Body, &req); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.contents = append(p.contents, req.Content)
+	p.mu.Unlock()
+	p.published <- struct{}{}
+	return fakeConfirmation{confirm: p.confirm}, nil
+}
+
+func (p *fakePublisher) publishedContents() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.contents...)
+}
+
+type fakeConfirmation struct {
+	confirm chan struct{}
+}
+
+func (c fakeConfirmation) Wait(ctx context.Context) error {
+	select {
+	case <-c.confirm:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestAsyncWritterFlush(t *testing.T) {
+	t.Parallel()
+	publisher := newFakePublisher()
+	w := newAsyncWritter(publisher, AsyncConfig{})
+	defer w.Close()
+
+	for _, content := range []string{"1", "2", "3"} {
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Write() unexpected error = %v", err)
+		}
+	}
+
+	// Flush waits for the broker to confirm the logs.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush() before the confirmations error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(publisher.confirm)
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() unexpected error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "3"}, publisher.publishedContents()); diff != "" {
+		t.Errorf("published logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsyncWritterCloseTimeout(t *testing.T) {
+	t.Parallel()
+	publisher := newFakePublisher()
+	var mu sync.Mutex
+	var errs []error
+	w := newAsyncWritter(publisher, AsyncConfig{
+		CloseTimeout: 20 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+
+	if _, err := w.Write([]byte("unconfirmed")); err != nil {
+		t.Fatalf("Write() unexpected error = %v", err)
+	}
+	<-publisher.published
+
+	start := time.Now()
+	if err := w.Close(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close() took %v, want about the close timeout", elapsed)
+	}
+
+	// The publishing still waiting for its confirmation is reported.
+	mu.Lock()
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("OnError() calls = %v, want one for the aborted publishing", errs)
+	}
+	mu.Unlock()
+
+	if _, err := w.Write([]byte("late")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Write() after Close error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestAsyncWritterOverflow(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		policy      OverflowPolicy
+		wantBlocked bool
+		wantLogs    []string
+		wantDropped uint64
+	}{
+		"block":       {policy: OverflowBlock, wantBlocked: true, wantLogs: []string{"1", "2", "3", "4"}},
+		"drop oldest": {policy: OverflowDropOldest, wantLogs: []string{"1", "3", "4"}, wantDropped: 1},
+		"drop newest": {policy: OverflowDropNewest, wantLogs: []string{"1", "2", "3"}, wantDropped: 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			publisher := newFakePublisher()
+			w := newAsyncWritter(publisher, AsyncConfig{BufferSize: 2, MaxInFlight: 1, Overflow: tc.policy})
+			defer w.Close()
+
+			// The first log waits for its confirmation, so that the next
+			// ones fill the buffer.
+			if _, err := w.Write([]byte("1")); err != nil {
+				t.Fatalf("Write() unexpected error = %v", err)
+			}
+			<-publisher.published
+			for _, content := range []string{"2", "3"} {
+				if _, err := w.Write([]byte(content)); err != nil {
+					t.Fatalf("Write() unexpected error = %v", err)
+				}
+			}
+
+			written := make(chan error, 1)
+			go func() {
+				_, err := w.Write([]byte("4"))
+				written <- err
+			}()
+			select {
+			case err := <-written:
+				if tc.wantBlocked {
+					t.Fatalf("Write() to a full buffer returned %v, want it to block", err)
+				}
+				if err != nil {
+					t.Fatalf("Write() unexpected error = %v", err)
+				}
+			case <-time.After(20 * time.Millisecond):
+				if !tc.wantBlocked {
+					t.Fatalf("Write() to a full buffer blocked")
+				}
+			}
+
+			close(publisher.confirm)
+			if tc.wantBlocked {
+				if err := <-written; err != nil {
+					t.Fatalf("Write() unexpected error = %v", err)
+				}
+			}
+			if err := w.Flush(context.Background()); err != nil {
+				t.Fatalf("Flush() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tc.wantLogs, publisher.publishedContents()); diff != "" {
+				t.Errorf("published logs mismatch (-want +got):\n%s", diff)
+			}
+			if d := w.Dropped(); d != tc.wantDropped {
+				t.Errorf("Dropped() = %d, want %d", d, tc.wantDropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("logger: writer is closed")
+
+// OverflowPolicy tells what an AsyncWritter does with a log written while
+// its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Write wait for room in the buffer.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered log to make room.
+	OverflowDropOldest
+	// OverflowDropNewest drops the log being written.
+	OverflowDropNewest
+)
+
+// logBuffer is a bounded FIFO of encoded logs waiting to be published.
+type logBuffer struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	items    [][]byte
+	size     int
+	policy   OverflowPolicy
+	closed   bool
+	// unsettled counts the buffered logs and the ones being published.
+	unsettled int
+	// idle is closed whenever unsettled drops to zero.
+	idle    chan struct{}
+	dropped uint64
+}
+
+func newLogBuffer(size int, policy OverflowPolicy) *logBuffer {
+	b := &logBuffer{
+		size:   size,
+		policy: policy,
+		idle:   make(chan struct{}),
+	}
+	close(b.idle)
+	b.notEmpty = sync.NewCond(&b.mu)
+	b.notFull = sync.NewCond(&b.mu)
+	return b
+}
+
+// push appends item, applying the overflow policy when the buffer is full.
+func (b *logBuffer) push(item []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for b.policy == OverflowBlock && len(b.items) >= b.size && !b.closed {
+		b.notFull.Wait()
+	}
+	if b.closed {
+		return ErrClosed
+	}
+	if len(b.items) >= b.size {
+		b.dropped++
+		if b.policy == OverflowDropNewest {
+			return nil
+		}
+		// Replace the oldest item, which leaves unsettled as is.
+		b.items[0] = nil
+		b.items = append(b.items[1:], item)
+		return nil
+	}
+
+	if b.unsettled == 0 {
+		b.idle = make(chan struct{})
+	}
+	b.items = append(b.items, item)
+	b.unsettled++
+	b.notEmpty.Signal()
+	return nil
+}
+
+// popBatch removes and returns up to max items, waiting for one if the
+// buffer is empty. ok is false once the buffer is closed and empty. The
+// items must be settled once published.
+func (b *logBuffer) popBatch(max int) (items [][]byte, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.items) == 0 && !b.closed {
+		b.notEmpty.Wait()
+	}
+	if len(b.items) == 0 {
+		return nil, false
+	}
+	n := min(max, len(b.items))
+	items = make([][]byte, n)
+	copy(items, b.items)
+	clear(b.items[:n])
+	b.items = b.items[n:]
+	b.notFull.Broadcast()
+	return items, true
+}
+
+// settle marks n popped items as published or failed.
+func (b *logBuffer) settle(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unsettled -= n
+	if b.unsettled == 0 {
+		close(b.idle)
+	}
+}
+
+// waitIdle waits until every pushed item was settled or dropped.
+func (b *logBuffer) waitIdle(ctx context.Context) error {
+	b.mu.Lock()
+	idle := b.idle
+	b.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close makes push fail and popBatch return the remaining items, then stop.
+func (b *logBuffer) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+}
+
+// droppedCount returns the number of logs dropped by the overflow policy.
+func (b *logBuffer) droppedCount() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLogBufferOverflow(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		policy      OverflowPolicy
+		wantItems   []string
+		wantDropped uint64
+	}{
+		"drop oldest": {policy: OverflowDropOldest, wantItems: []string{"2", "3"}, wantDropped: 1},
+		"drop newest": {policy: OverflowDropNewest, wantItems: []string{"1", "2"}, wantDropped: 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			b := newLogBuffer(2, tc.policy)
+			for _, item := range []string{"1", "2", "3"} {
+				if err := b.push([]byte(item)); err != nil {
+					t.Fatalf("push() unexpected error = %v", err)
+				}
+			}
+
+			items, ok := b.popBatch(10)
+			if !ok {
+				t.Fatalf("popBatch() returned no items")
+			}
+			var got []string
+			for _, item := range items {
+				got = append(got, string(item))
+			}
+			if diff := cmp.Diff(tc.wantItems, got); diff != "" {
+				t.Errorf("popBatch() mismatch (-want +got):\n%s", diff)
+			}
+			if d := b.droppedCount(); d != tc.wantDropped {
+				t.Errorf("droppedCount() = %d, want %d", d, tc.wantDropped)
+			}
+
+			b.settle(len(items))
+			if err := b.waitIdle(context.Background()); err != nil {
+				t.Errorf("waitIdle() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLogBufferBlock(t *testing.T) {
+	t.Parallel()
+	b := newLogBuffer(1, OverflowBlock)
+	if err := b.push([]byte("1")); err != nil {
+		t.Fatalf("push() unexpected error = %v", err)
+	}
+
+	pushed := make(chan error, 1)
+	go func() { pushed <- b.push([]byte("2")) }()
+
+	select {
+	case <-pushed:
+		t.Fatalf("push() returned while the buffer was full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	items, _ := b.popBatch(10)
+	if err := <-pushed; err != nil {
+		t.Errorf("push() unexpected error = %v", err)
+	}
+
+	// The second item is not settled yet.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	b.settle(len(items))
+	if err := b.waitIdle(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitIdle() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLogBufferClose(t *testing.T) {
+	t.Parallel()
+	b := newLogBuffer(1, OverflowBlock)
+	if err := b.push([]byte("1")); err != nil {
+		t.Fatalf("push() unexpected error = %v", err)
+	}
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- b.push([]byte("2")) }()
+	time.Sleep(10 * time.Millisecond)
+	b.close()
+
+	if err := <-blocked; !errors.Is(err, ErrClosed) {
+		t.Errorf("push() blocked on a full buffer error = %v, want %v", err, ErrClosed)
+	}
+	if err := b.push([]byte("3")); !errors.Is(err, ErrClosed) {
+		t.Errorf("push() after close error = %v, want %v", err, ErrClosed)
+	}
+
+	// The remaining items are still handed out after close.
+	if items, ok := b.popBatch(10); !ok || len(items) != 1 {
+		t.Errorf("popBatch() = %d items, %v, want the remaining item", len(items), ok)
+	}
+	if _, ok := b.popBatch(10); ok {
+		t.Errorf("popBatch() on a closed and empty buffer returned ok")
+	}
+}
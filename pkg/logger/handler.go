@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"log_service/internal/server/domain"
+	serverPresentation "log_service/internal/server/presentation"
+)
+
+// LevelFatal is the slog level mapped to the FATAL severity. The levels
+// between slog.LevelError and LevelFatal are mapped to ERROR.
+const LevelFatal = slog.LevelError + 4
+
+// HandlerOptions configures a Handler. A nil *HandlerOptions is valid and
+// uses the defaults.
+type HandlerOptions struct {
+	// SourceService is the source service of the logs. It defaults to the
+	// source service of the publisher.
+	SourceService string
+	// Level is the minimum level of the published records. It defaults to
+	// slog.LevelInfo.
+	Level slog.Leveler
+}
+
+// Handler is a slog.Handler publishing every record as a log:
+//
+//	slog.SetDefault(slog.New(logger.NewHandler(w, &logger.HandlerOptions{SourceService: "billing"})))
+//
+// The message of the record is the content of the log and its attributes are
+// the attributes of the log, groups becoming nested objects. The top-level
+// string attributes named after a field of the log, such as
+// destination_service, request_type or trace_id, set that field instead.
+type Handler struct {
+	pub  LogPublisher
+	opts HandlerOptions
+	// goas are the groups and attributes added by WithGroup and WithAttrs,
+	// in order.
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or a list of attributes.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewHandler returns a handler publishing the records to pub, which is
+// usually a *Writter or an *AsyncWritter.
+func NewHandler(pub LogPublisher, opts *HandlerOptions) *Handler {
+	h := &Handler{pub: pub}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	return h
+}
+
+// Enabled reports whether records of the given level are published.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle publishes r.
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	req := serverPresentation.AMQPLogRequest{
+		LogLevel:      LevelSeverity(r.Level).String(),
+		Date:          r.Time,
+		SourceService: h.opts.SourceService,
+		Content:       r.Message,
+	}
+
+	var path []string
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			path = append(path, goa.group)
+			continue
+		}
+		for _, a := range goa.attrs {
+			addAttr(&req, path, a)
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(&req, path, a)
+		return true
+	})
+
+	return h.pub.WriteLog(req)
+}
+
+// WithAttrs returns a handler adding attrs to every record, within the
+// groups opened so far.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup returns a handler nesting the attributes added afterwards under name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *Handler) with(goa groupOrAttrs) *Handler {
+	h2 := *h
+	h2.goas = append(slices.Clip(h.goas), goa)
+	return &h2
+}
+
+// LevelSeverity returns the severity of a slog level. The levels below
+// slog.LevelDebug are TRACE and the levels from LevelFatal on are FATAL.
+func LevelSeverity(level slog.Level) domain.Severity {
+	switch {
+	case level < slog.LevelDebug:
+		return domain.SeverityTrace
+	case level < slog.LevelInfo:
+		return domain.SeverityDebug
+	case level < slog.LevelWarn:
+		return domain.SeverityInfo
+	case level < slog.LevelError:
+		return domain.SeverityWarn
+	case level < LevelFatal:
+		return domain.SeverityError
+	default:
+		return domain.SeverityFatal
+	}
+}
+
+// addAttr adds a to the attributes of req, nested under the groups of path.
+// The nested objects are only created once an attribute is added to them, so
+// that empty groups are left out.
+func addAttr(req *serverPresentation.AMQPLogRequest, path []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			path = append(slices.Clip(path), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			addAttr(req, path, ga)
+		}
+		return
+	}
+
+	if len(path) == 0 && a.Value.Kind() == slog.KindString && setField(req, a.Key, a.Value.String()) {
+		return
+	}
+
+	if req.Attributes == nil {
+		req.Attributes = make(map[string]any)
+	}
+	attrs := req.Attributes
+	for _, group := range path {
+		nested, ok := attrs[group].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			attrs[group] = nested
+		}
+		attrs = nested
+	}
+	attrs[a.Key] = attrValue(a.Value)
+}
+
+// setField sets the field of req named key, if any, and reports whether it did.
+func setField(req *serverPresentation.AMQPLogRequest, key, value string) bool {
+	switch key {
+	case "destination_service":
+		req.DestinationService = value
+	case "request_type":
+		req.RequestType = value
+	case "trace_id":
+		req.TraceID = value
+	case "span_id":
+		req.SpanID = value
+	case "parent_span_id":
+		req.ParentSpanID = value
+	default:
+		return false
+	}
+	return true
+}
+
+// attrValue returns the JSON-encodable form of a resolved, non-group value.
+func attrValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return v.Any()
+	default:
+		return v.Any()
+	}
+}
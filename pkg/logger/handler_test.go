@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"log_service/internal/server/domain"
+	serverPresentation "log_service/internal/server/presentation"
+)
+
+// recordingPublisher records the published logs.
+type recordingPublisher struct {
+	reqs []serverPresentation.AMQPLogRequest
+	err  error
+}
+
+func (p *recordingPublisher) WriteLog(req serverPresentation.AMQPLogRequest) error {
+	p.reqs = append(p.reqs, req)
+	return p.err
+}
+
+func TestHandlerConformance(t *testing.T) {
+	t.Parallel()
+	pub := &recordingPublisher{}
+	results := func() []map[string]any {
+		ms := make([]map[string]any, 0, len(pub.reqs))
+		for _, req := range pub.reqs {
+			m := map[string]any{
+				slog.LevelKey:   req.LogLevel,
+				slog.MessageKey: req.Content,
+			}
+			if !req.Date.IsZero() {
+				m[slog.TimeKey] = req.Date
+			}
+			for k, v := range req.Attributes {
+				m[k] = v
+			}
+			ms = append(ms, m)
+		}
+		return ms
+	}
+
+	if err := slogtest.TestHandler(NewHandler(pub, &HandlerOptions{Level: slog.LevelDebug}), results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandlerHandle(t *testing.T) {
+	t.Parallel()
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pub := &recordingPublisher{}
+	logger := slog.New(NewHandler(pub, &HandlerOptions{SourceService: "billing"})).
+		With("destination_service", "UserService", "user", "alice").
+		WithGroup("http")
+
+	r := slog.NewRecord(date, slog.LevelWarn, "slow request", 0)
+	r.AddAttrs(
+		slog.String("request_type", "POST"),
+		slog.Duration("elapsed", 1500*time.Millisecond),
+		slog.Any("err", errors.New("timeout")),
+		slog.Group("", slog.Int("status", 504)),
+		slog.Group("empty"),
+	)
+	if err := logger.Handler().Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+
+	want := []serverPresentation.AMQPLogRequest{{
+		LogLevel:           "WARN",
+		Date:               date,
+		SourceService:      "billing",
+		DestinationService: "UserService",
+		Content:            "slow request",
+		Attributes: map[string]any{
+			"user": "alice",
+			"http": map[string]any{
+				"request_type": "POST",
+				"elapsed":      "1.5s",
+				"err":          "timeout",
+				"status":       int64(504),
+			},
+		},
+	}}
+	if diff := cmp.Diff(want, pub.reqs); diff != "" {
+		t.Errorf("published logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandlerEnabled(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		opts  *HandlerOptions
+		level slog.Level
+		want  bool
+	}{
+		"default below info":  {opts: nil, level: slog.LevelDebug, want: false},
+		"default info":        {opts: nil, level: slog.LevelInfo, want: true},
+		"configured below":    {opts: &HandlerOptions{Level: slog.LevelError}, level: slog.LevelWarn, want: false},
+		"configured at level": {opts: &HandlerOptions{Level: slog.LevelError}, level: slog.LevelError, want: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			h := NewHandler(&recordingPublisher{}, tc.opts)
+			if got := h.Enabled(context.Background(), tc.level); got != tc.want {
+				t.Errorf("Enabled(%v) = %v, want %v", tc.level, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLevelSeverity(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		level slog.Level
+		want  domain.Severity
+	}{
+		"below debug": {level: slog.LevelDebug - 1, want: domain.SeverityTrace},
+		"debug":       {level: slog.LevelDebug, want: domain.SeverityDebug},
+		"info":        {level: slog.LevelInfo, want: domain.SeverityInfo},
+		"above info":  {level: slog.LevelInfo + 2, want: domain.SeverityInfo},
+		"warn":        {level: slog.LevelWarn, want: domain.SeverityWarn},
+		"error":       {level: slog.LevelError, want: domain.SeverityError},
+		"above error": {level: slog.LevelError + 3, want: domain.SeverityError},
+		"fatal":       {level: LevelFatal, want: domain.SeverityFatal},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := LevelSeverity(tc.level); got != tc.want {
+				t.Errorf("LevelSeverity(%v) = %v, want %v", tc.level, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrEntryTooLarge is returned when a log does not fit in the spool at all.
+var ErrEntryTooLarge = errors.New("logger: log is larger than the spool")
+
+const (
+	segmentExt = ".log"
+	cursorFile = "cursor"
+)
+
+// spoolCursor is a read position in the spool.
+type spoolCursor struct {
+	segment uint64
+	offset  int64
+}
+
+// spool is a write-ahead queue of encoded logs stored in a directory.
+//
+// Logs are appended as lines to numbered segment files, the last one being
+// the active segment. The position of the first unpublished log is saved in
+// the cursor file, and the segments before it are removed. Once the spool
+// exceeds maxBytes, its oldest segments are removed, published or not.
+type spool struct {
+	dir         string
+	maxBytes    int64
+	segmentSize int64
+
+	mu sync.Mutex
+	// segments are the ids of the segment files in order, the last one being
+	// the active segment.
+	segments []uint64
+	// sizes and entries are the size and number of logs of every segment.
+	sizes   map[uint64]int64
+	entries map[uint64]int
+	size    int64
+	active  *os.File
+	read    spoolCursor
+	closed  bool
+	dropped uint64
+	// notify is signaled whenever a log is appended.
+	notify chan struct{}
+	// idle is closed whenever every appended log was committed or dropped.
+	idle   chan struct{}
+	isIdle bool
+}
+
+// openSpool opens the spool stored in dir, creating it if needed. Appends go
+// to a new segment so that a line left incomplete by a crash is never
+// continued. A corrupt cursor file is reported to onError, and the spool is
+// then read again from its oldest segment: logs may be published twice
+// rather than lost.
+func openSpool(dir string, maxBytes, segmentSize int64, onError func(error)) (*spool, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &spool{
+		dir:         dir,
+		maxBytes:    maxBytes,
+		segmentSize: segmentSize,
+		sizes:       make(map[uint64]int64),
+		entries:     make(map[uint64]int),
+		notify:      make(chan struct{}, 1),
+		idle:        make(chan struct{}),
+	}
+
+	names, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		id, ok := segmentID(name.Name())
+		if !ok {
+			continue
+		}
+		size, entries, err := s.scanSegment(id)
+		if err != nil {
+			return nil, err
+		}
+		s.segments = append(s.segments, id)
+		s.sizes[id] = size
+		s.entries[id] = entries
+		s.size += size
+	}
+	slices.Sort(s.segments)
+
+	var next uint64 = 1
+	if len(s.segments) > 0 {
+		next = s.segments[len(s.segments)-1] + 1
+	}
+	if err := s.openSegment(next); err != nil {
+		return nil, err
+	}
+
+	s.read = spoolCursor{segment: s.segments[0]}
+	cur, err := s.loadCursor()
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		onError(fmt.Errorf("reading the spool again from its oldest log: %w", err))
+	case !slices.Contains(s.segments, cur.segment):
+		// The segment was removed after the cursor was saved.
+	case cur.offset < 0 || cur.offset > s.sizes[cur.segment]:
+		onError(fmt.Errorf("reading the spool again from its oldest log: spool cursor %d is past the end of segment %d", cur.offset, cur.segment))
+	default:
+		s.read = cur
+	}
+	s.updateIdle()
+	return s, nil
+}
+
+// append adds an encoded log, which must not contain a newline, to the spool.
+func (s *spool) append(entry []byte) error {
+	line := int64(len(entry)) + 1
+	if line > s.maxBytes || line > s.segmentSize {
+		return ErrEntryTooLarge
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	id := s.activeID()
+	if s.sizes[id] > 0 && s.sizes[id]+line > s.segmentSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		id = s.activeID()
+	}
+	for s.size+line > s.maxBytes {
+		if err := s.dropOldest(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.active.Write(append(entry, '\n')); err != nil {
+		return err
+	}
+	// The log is only spooled once it survives a crash of the machine.
+	if err := s.active.Sync(); err != nil {
+		return err
+	}
+	s.sizes[id] += line
+	s.entries[id]++
+	s.size += line
+	s.updateIdle()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// next returns up to max logs following the read position, and the cursor
+// to commit once they are published. It returns no logs when the spool is
+// fully read.
+func (s *spool) next(max int) ([][]byte, spoolCursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.read
+	var entries [][]byte
+	for len(entries) < max {
+		f, err := os.Open(s.segmentPath(cur.segment))
+		if err != nil {
+			return nil, cur, err
+		}
+		if _, err := f.Seek(cur.offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, cur, err
+		}
+		r := bufio.NewReader(f)
+		for len(entries) < max {
+			line, err := r.ReadBytes('\n')
+			if err != nil {
+				// A line without a newline was cut short by a crash. It is
+				// only skipped once the segment is no longer active.
+				break
+			}
+			cur.offset += int64(len(line))
+			entries = append(entries, line[:len(line)-1])
+		}
+		f.Close()
+
+		if len(entries) == max || cur.segment == s.activeID() {
+			break
+		}
+		i, _ := slices.BinarySearch(s.segments, cur.segment)
+		cur = spoolCursor{segment: s.segments[i+1]}
+	}
+	return entries, cur, nil
+}
+
+// commit moves the read position to cur, removing the segments before it.
+// Cursors left behind by dropped segments are ignored.
+func (s *spool) commit(cur spoolCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cur.segment < s.read.segment || (cur.segment == s.read.segment && cur.offset <= s.read.offset) {
+		return nil
+	}
+	for s.segments[0] < cur.segment {
+		if err := s.removeSegment(s.segments[0]); err != nil {
+			return err
+		}
+	}
+	s.read = cur
+	s.updateIdle()
+	return s.saveCursor()
+}
+
+// waitIdle returns the channel closed once every appended log is committed
+// or dropped.
+func (s *spool) waitIdle() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idle
+}
+
+// droppedCount returns the number of logs dropped to cap the spool size.
+func (s *spool) droppedCount() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// close makes append fail and closes the active segment.
+func (s *spool) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.active.Close()
+}
+
+func (s *spool) activeID() uint64 {
+	return s.segments[len(s.segments)-1]
+}
+
+// dropOldest removes the oldest segment, rotating the active segment first
+// if it is the only one.
+func (s *spool) dropOldest() error {
+	if len(s.segments) == 1 {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	id := s.segments[0]
+	if s.read.segment == id {
+		s.dropped += uint64(s.entries[id] - s.readEntries())
+		s.read = spoolCursor{segment: s.segments[1]}
+		if err := s.saveCursor(); err != nil {
+			return err
+		}
+	}
+	return s.removeSegment(id)
+}
+
+// readEntries counts the logs of the read segment before the read position.
+func (s *spool) readEntries() int {
+	if s.read.offset == 0 {
+		return 0
+	}
+	f, err := os.Open(s.segmentPath(s.read.segment))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	n, _ := countLines(io.LimitReader(f, s.read.offset))
+	return n
+}
+
+// rotate closes the active segment and opens the next one.
+func (s *spool) rotate() error {
+	if err := s.active.Close(); err != nil {
+		return err
+	}
+	return s.openSegment(s.activeID() + 1)
+}
+
+func (s *spool) openSegment(id uint64) error {
+	f, err := os.OpenFile(s.segmentPath(id), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	// Make the new file itself durable, not only its content.
+	if err := syncDir(s.dir); err != nil {
+		f.Close()
+		return err
+	}
+	s.active = f
+	s.segments = append(s.segments, id)
+	s.sizes[id] = 0
+	s.entries[id] = 0
+	return nil
+}
+
+func (s *spool) removeSegment(id uint64) error {
+	if err := os.Remove(s.segmentPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.segments = slices.DeleteFunc(s.segments, func(seg uint64) bool { return seg == id })
+	s.size -= s.sizes[id]
+	delete(s.sizes, id)
+	delete(s.entries, id)
+	return nil
+}
+
+// updateIdle opens or closes the idle channel depending on whether the read
+// position reached the end of the active segment.
+func (s *spool) updateIdle() {
+	caughtUp := s.read.segment == s.activeID() && s.read.offset >= s.sizes[s.activeID()]
+	switch {
+	case caughtUp && !s.isIdle:
+		close(s.idle)
+		s.isIdle = true
+	case !caughtUp && s.isIdle:
+		s.idle = make(chan struct{})
+		s.isIdle = false
+	}
+}
+
+func (s *spool) scanSegment(id uint64) (size int64, entries int, err error) {
+	f, err := os.Open(s.segmentPath(id))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	entries, err = countLines(f)
+	return info.Size(), entries, err
+}
+
+// saveCursor atomically replaces the cursor file with the read position. The
+// new cursor is synced to disk before it replaces the old one, so that a
+// crash leaves either of them in place.
+func (s *spool) saveCursor() error {
+	tmp := filepath.Join(s.dir, cursorFile+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "%d %d\n", s.read.segment, s.read.offset)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, cursorFile)); err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+// syncDir flushes the entries of dir, such as created and renamed files, to disk.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+func (s *spool) loadCursor() (spoolCursor, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, cursorFile))
+	if err != nil {
+		return spoolCursor{}, err
+	}
+	var cur spoolCursor
+	if _, err := fmt.Sscanf(string(data), "%d %d", &cur.segment, &cur.offset); err != nil {
+		return spoolCursor{}, fmt.Errorf("invalid spool cursor %q: %w", data, err)
+	}
+	return cur, nil
+}
+
+func (s *spool) segmentPath(id uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+// segmentID parses the id of a segment file name.
+func segmentID(name string) (uint64, bool) {
+	base, ok := strings.CutSuffix(name, segmentExt)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(base, 10, 64)
+	return id, err == nil
+}
+
+func countLines(r io.Reader) (int, error) {
+	buf := make([]byte, 32*1024)
+	n := 0
+	for {
+		c, err := r.Read(buf)
+		n += bytes.Count(buf[:c], []byte{'\n'})
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
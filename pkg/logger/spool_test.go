@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// readAll reads and commits everything left in s.
+func readAll(t *testing.T, s *spool) []string {
+	t.Helper()
+	var got []string
+	for {
+		entries, cur, err := s.next(3)
+		if err != nil {
+			t.Fatalf("next() unexpected error = %v", err)
+		}
+		if err := s.commit(cur); err != nil {
+			t.Fatalf("commit() unexpected error = %v", err)
+		}
+		if len(entries) == 0 {
+			return got
+		}
+		for _, entry := range entries {
+			got = append(got, string(entry))
+		}
+	}
+}
+
+func appendAll(t *testing.T, s *spool, entries ...string) {
+	t.Helper()
+	for _, entry := range entries {
+		if err := s.append([]byte(entry)); err != nil {
+			t.Fatalf("append(%q) unexpected error = %v", entry, err)
+		}
+	}
+}
+
+// failOnError returns an onError callback failing the test.
+func failOnError(t *testing.T) func(error) {
+	return func(err error) {
+		t.Errorf("onError() unexpected error = %v", err)
+	}
+}
+
+func isIdle(s *spool) bool {
+	select {
+	case <-s.waitIdle():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSpoolReadsInOrderAcrossSegments(t *testing.T) {
+	t.Parallel()
+	s, err := openSpool(t.TempDir(), 1024, 8, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+	defer s.close()
+
+	if !isIdle(s) {
+		t.Errorf("empty spool is not idle")
+	}
+	want := []string{"a1", "b2", "c3", "d4", "e5", "f6", "g7"}
+	appendAll(t, s, want...)
+	if isIdle(s) {
+		t.Errorf("spool with unread logs is idle")
+	}
+
+	if diff := cmp.Diff(want, readAll(t, s)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+	if !isIdle(s) {
+		t.Errorf("fully committed spool is not idle")
+	}
+	if len(s.segments) != 1 {
+		t.Errorf("segments = %v, want only the active one", s.segments)
+	}
+}
+
+func TestSpoolResumesAfterReopen(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := openSpool(dir, 1024, 8, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+	appendAll(t, s, "a1", "b2", "c3", "d4")
+
+	// Commit the first two logs only, as if the application stopped then.
+	entries, cur, err := s.next(2)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("next(2) = %q, %v", entries, err)
+	}
+	if err := s.commit(cur); err != nil {
+		t.Fatalf("commit() unexpected error = %v", err)
+	}
+	if err := s.close(); err != nil {
+		t.Fatalf("close() unexpected error = %v", err)
+	}
+
+	s, err = openSpool(dir, 1024, 8, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+	defer s.close()
+	appendAll(t, s, "e5")
+
+	want := []string{"c3", "d4", "e5"}
+	if diff := cmp.Diff(want, readAll(t, s)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpoolDropsOldestWhenFull(t *testing.T) {
+	t.Parallel()
+	// Every log takes 3 bytes, so that a segment holds 2 logs and the spool 4.
+	s, err := openSpool(t.TempDir(), 12, 6, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+	defer s.close()
+
+	appendAll(t, s, "a1", "b2", "c3", "d4", "e5", "f6")
+
+	if got := s.droppedCount(); got != 2 {
+		t.Errorf("droppedCount() = %d, want 2", got)
+	}
+	if s.size > 12 {
+		t.Errorf("spool size = %d, want at most 12", s.size)
+	}
+	want := []string{"c3", "d4", "e5", "f6"}
+	if diff := cmp.Diff(want, readAll(t, s)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpoolAppendErrors(t *testing.T) {
+	t.Parallel()
+	s, err := openSpool(t.TempDir(), 12, 6, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+
+	if err := s.append([]byte("too large")); !errors.Is(err, ErrEntryTooLarge) {
+		t.Errorf("append() error = %v, want %v", err, ErrEntryTooLarge)
+	}
+	if err := s.close(); err != nil {
+		t.Fatalf("close() unexpected error = %v", err)
+	}
+	if err := s.append([]byte("a1")); !errors.Is(err, ErrClosed) {
+		t.Errorf("append() after close error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestSpoolSkipsTruncatedLine(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	// A segment left by a crash in the middle of a write.
+	if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%020d%s", 1, segmentExt)), []byte("a1\nb2\nc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := openSpool(dir, 1024, 64, failOnError(t))
+	if err != nil {
+		t.Fatalf("openSpool() unexpected error = %v", err)
+	}
+	defer s.close()
+	appendAll(t, s, "d4")
+
+	want := []string{"a1", "b2", "d4"}
+	if diff := cmp.Diff(want, readAll(t, s)); diff != "" {
+		t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpoolCorruptCursor(t *testing.T) {
+	t.Parallel()
+	for name, cursor := range map[string]string{
+		"malformed":       "garbage",
+		"past the end":    "1 4096\n",
+		"negative offset": "1 -3\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			s, err := openSpool(dir, 1024, 64, failOnError(t))
+			if err != nil {
+				t.Fatalf("openSpool() unexpected error = %v", err)
+			}
+			appendAll(t, s, "a1", "b2")
+			if err := s.close(); err != nil {
+				t.Fatalf("close() unexpected error = %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, cursorFile), []byte(cursor), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var errs []error
+			s, err = openSpool(dir, 1024, 64, func(err error) { errs = append(errs, err) })
+			if err != nil {
+				t.Fatalf("openSpool() with a corrupt cursor error = %v, want the spool to be read again", err)
+			}
+			defer s.close()
+			if len(errs) != 1 {
+				t.Errorf("onError() calls = %v, want one for the corrupt cursor", errs)
+			}
+
+			// The spool is read again from its oldest log.
+			want := []string{"a1", "b2"}
+			if diff := cmp.Diff(want, readAll(t, s)); diff != "" {
+				t.Errorf("read logs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
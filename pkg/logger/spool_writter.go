@@ -0,0 +1,288 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	clientRabbitmq "log_service/internal/client/infrastructure/rabbitmq"
+	"log_service/internal/server/infrastructure/rabbitmq"
+	serverPresentation "log_service/internal/server/presentation"
+)
+
+const (
+	DefaultSpoolMaxBytes     = 64 << 20
+	DefaultSpoolSegmentSize  = 1 << 20
+	DefaultReconnectMinDelay = time.Second
+	DefaultReconnectMaxDelay = 30 * time.Second
+	DefaultSpoolCloseTimeout = 5 * time.Second
+	DefaultSpoolMaxInFlight  = 64
+)
+
+// minSpoolSegments is the minimum number of segments a full spool is split
+// into, so that dropping the oldest one frees a fraction of the spool only.
+const minSpoolSegments = 4
+
+// SpoolConfig controls where a SpoolWritter spools logs and how it reaches
+// RabbitMQ. Zero-valued fields take their default.
+type SpoolConfig struct {
+	// Dir is the spool directory. It is required, and must not be shared by
+	// two writers at once.
+	Dir string
+	// MaxBytes caps the size of the spool. The oldest logs are dropped to
+	// make room for the new ones once it is reached.
+	MaxBytes int64
+	// SegmentSize is the size of the files the spool is split into, which
+	// is also the granularity at which logs are dropped.
+	SegmentSize int64
+	// URL is the RabbitMQ URL. It defaults to RABBITMQ_URL.
+	URL string
+	// ReconnectMinDelay and ReconnectMaxDelay bound the delay between two
+	// connection attempts, which doubles after every failed attempt.
+	ReconnectMinDelay time.Duration
+	ReconnectMaxDelay time.Duration
+	// MaxInFlight is the number of logs published before waiting for the
+	// broker to confirm them.
+	MaxInFlight int
+	// CloseTimeout bounds the time Close waits for the spooled logs to be
+	// published. The logs left are published by the next writer using Dir.
+	CloseTimeout time.Duration
+	// SourceService is the source service of the logs. It defaults to the
+	// name of the executable.
+	SourceService string
+	// OnError is called from the publishing goroutine when RabbitMQ cannot
+	// be reached or a log cannot be published. The logs are retried until
+	// they are published or dropped. It is also called by NewSpoolWritter if
+	// the read position of the spool is corrupt. Errors are printed to stderr
+	// by default.
+	OnError func(error)
+}
+
+// SpoolWritter is an io.Writer writing every write as a log to a spool
+// directory, from which the logs are published in order in the background.
+//
+// The writer connects to RabbitMQ on its own and reconnects whenever the
+// connection is lost, so that logs written while the broker is unavailable
+// are kept on disk and published once it is back, including after a restart
+// of the application. A log is published at least once: it may be published
+// again if the application stops before the broker confirms it.
+type SpoolWritter struct {
+	cfg    SpoolConfig
+	spool  *spool
+	dial   func(url string) (*amqp.Connection, error)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSpoolWritter opens the spool directory and starts publishing the logs
+// it holds, including the ones left by a previous writer.
+func NewSpoolWritter(cfg SpoolConfig) (*SpoolWritter, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("logger: spool directory is required")
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultSpoolMaxBytes
+	}
+	if cfg.SegmentSize <= 0 {
+		cfg.SegmentSize = DefaultSpoolSegmentSize
+	}
+	cfg.SegmentSize = max(min(cfg.SegmentSize, cfg.MaxBytes/minSpoolSegments), 1)
+	if cfg.URL == "" {
+		cfg.URL = os.Getenv("RABBITMQ_URL")
+	}
+	if cfg.ReconnectMinDelay <= 0 {
+		cfg.ReconnectMinDelay = DefaultReconnectMinDelay
+	}
+	if cfg.ReconnectMaxDelay < cfg.ReconnectMinDelay {
+		cfg.ReconnectMaxDelay = max(DefaultReconnectMaxDelay, cfg.ReconnectMinDelay)
+	}
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = DefaultSpoolMaxInFlight
+	}
+	if cfg.CloseTimeout <= 0 {
+		cfg.CloseTimeout = DefaultSpoolCloseTimeout
+	}
+	if cfg.SourceService == "" {
+		cfg.SourceService = filepath.Base(os.Args[0])
+	}
+	if cfg.OnError == nil {
+		cfg.OnError = func(err error) {
+			fmt.Fprintf(os.Stderr, "logger: failed to publish spooled logs: %v\n", err)
+		}
+	}
+
+	s, err := openSpool(cfg.Dir, cfg.MaxBytes, cfg.SegmentSize, cfg.OnError)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &SpoolWritter{
+		cfg:    cfg,
+		spool:  s,
+		dial:   amqp.Dial,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go w.run(ctx)
+	return w, nil
+}
+
+// Write spools p as the content of a log.
+func (w *SpoolWritter) Write(p []byte) (n int, err error) {
+	if err := w.WriteLog(serverPresentation.AMQPLogRequest{Content: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteLog spools req like Write spools its content.
+func (w *SpoolWritter) WriteLog(req serverPresentation.AMQPLogRequest) error {
+	if req.Date.IsZero() {
+		req.Date = time.Now()
+	}
+	if req.SourceService == "" {
+		req.SourceService = w.cfg.SourceService
+	}
+
+	// JSON escapes the newlines of strings, so that every log is one line.
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return w.spool.append(body)
+}
+
+// Flush waits until the logs written so far are confirmed by the broker or
+// dropped.
+func (w *SpoolWritter) Flush(ctx context.Context) error {
+	select {
+	case <-w.spool.waitIdle():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped returns the number of logs dropped because the spool was full.
+func (w *SpoolWritter) Dropped() uint64 {
+	return w.spool.droppedCount()
+}
+
+// Close waits up to CloseTimeout for the spooled logs to be published, then
+// stops publishing. The logs still unpublished are kept in the spool.
+func (w *SpoolWritter) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.CloseTimeout)
+	defer cancel()
+	flushErr := w.Flush(ctx)
+
+	w.cancel()
+	<-w.done
+	if err := w.spool.close(); err != nil {
+		return err
+	}
+	return flushErr
+}
+
+// run publishes the spooled logs, reconnecting with an exponential backoff
+// until the writer is closed.
+func (w *SpoolWritter) run(ctx context.Context) {
+	defer close(w.done)
+	delay := w.cfg.ReconnectMinDelay
+	for {
+		connected, err := w.publish(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		w.cfg.OnError(err)
+		if connected {
+			delay = w.cfg.ReconnectMinDelay
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay = min(delay*2, w.cfg.ReconnectMaxDelay)
+	}
+}
+
+// publish connects to RabbitMQ and publishes the spooled logs as they come
+// until an error occurs. connected reports whether the connection succeeded.
+func (w *SpoolWritter) publish(ctx context.Context) (connected bool, err error) {
+	conn, err := w.dial(w.cfg.URL)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return true, err
+	}
+	publisher, err := clientRabbitmq.NewPublisher(ch)
+	if err != nil {
+		return true, err
+	}
+
+	for {
+		bodies, cur, err := w.spool.next(w.cfg.MaxInFlight)
+		if err != nil {
+			return true, err
+		}
+		if len(bodies) > 0 {
+			if err := w.publishBatch(ctx, publisher, bodies); err != nil {
+				return true, err
+			}
+		}
+		if err := w.spool.commit(cur); err != nil {
+			return true, err
+		}
+		if len(bodies) > 0 {
+			continue
+		}
+
+		select {
+		case <-w.spool.notify:
+		case amqpErr := <-closed:
+			return true, fmt.Errorf("connection to RabbitMQ closed: %v", amqpErr)
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+}
+
+// publishBatch publishes bodies, then waits for the broker to confirm all of
+// them. The batch is published again from the spool if any of them fails,
+// including when the log queue does not exist yet.
+func (w *SpoolWritter) publishBatch(ctx context.Context, publisher *clientRabbitmq.Publisher, bodies [][]byte) error {
+	confirmations := make([]*clientRabbitmq.Confirmation, 0, len(bodies))
+	for _, body := range bodies {
+		confirmation, err := publisher.Publish(ctx,
+			"",                      // exchange
+			rabbitmq.LOG_QUEUE_NAME, // routing key
+			amqp.Publishing{
+				ContentType:  "text/plain",
+				DeliveryMode: amqp.Persistent,
+				Body:         body,
+			})
+		if err != nil {
+			return err
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+
+	for _, confirmation := range confirmations {
+		if err := confirmation.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
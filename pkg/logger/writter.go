@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,15 +13,21 @@ import (
 	serverPresentation "log_service/internal/server/presentation"
 )
 
+// Writter is an io.Writer publishing every write as a log and waiting for
+// the server to store it. It is safe for concurrent use.
 type Writter struct {
 	ch              *amqp.Channel
 	logPresentation presentation.ILogPresentation
 	queue           string
-	msgs            <-chan amqp.Delivery
+	replies         *presentation.ReplyDispatcher
+	sourceService   string
 }
 
 func NewWritter(ctx context.Context, ch *amqp.Channel) (*Writter, error) {
-	logPresentation := presentation.NewLogPresentation(ch)
+	logPresentation, err := presentation.NewLogPresentation(ch)
+	if err != nil {
+		return nil, err
+	}
 	msgs, queue, err := logPresentation.Consume()
 	if err != nil {
 		return nil, err
@@ -28,23 +36,70 @@ func NewWritter(ctx context.Context, ch *amqp.Channel) (*Writter, error) {
 		ch:              ch,
 		logPresentation: logPresentation,
 		queue:           queue,
-		msgs:            msgs,
+		replies:         presentation.NewReplyDispatcher(msgs),
+		sourceService:   filepath.Base(os.Args[0]),
 	}
 	return w, nil
 }
 
+// DefaultWriteTimeout bounds the time Writter.Write and Writter.WriteLog wait
+// for the server to store a log.
+const DefaultWriteTimeout = 5 * time.Second
+
+var (
+	// ErrNoResponse is returned when the reply queue is closed before the
+	// server responded.
+	ErrNoResponse = presentation.ErrNoResponse
+	// ErrInvalidArgument is wrapped by the ResponseError of a log rejected by
+	// the server.
+	ErrInvalidArgument = presentation.ErrInvalidArgument
+	// ErrInternal is wrapped by the ResponseError of a log the server failed
+	// to store.
+	ErrInternal = presentation.ErrInternal
+)
+
+// ResponseError is returned by Writter when the server did not store a log.
+type ResponseError = presentation.ResponseError
+
+// LogPublisher publishes structured logs. It is implemented by *Writter,
+// *AsyncWritter and *SpoolWritter.
+type LogPublisher interface {
+	// WriteLog publishes req, defaulting its date to now and its source
+	// service to the one of the publisher.
+	WriteLog(req serverPresentation.AMQPLogRequest) error
+}
+
 func (w *Writter) Write(p []byte) (n int, err error) {
-	req := serverPresentation.AMQPLogRequest{
-		Date:    time.Now(),
-		Content: string(p),
+	if err := w.WriteLog(serverPresentation.AMQPLogRequest{Content: string(p)}); err != nil {
+		return 0, err
 	}
+	return len(p), nil
+}
 
-	id := uuid.New().String()
-	if err := w.logPresentation.Publish(context.Background(), w.queue, id, req); err != nil {
-		return 0, err
+// WriteLog publishes req and waits up to DefaultWriteTimeout for the server
+// to store it.
+func (w *Writter) WriteLog(req serverPresentation.AMQPLogRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
+	defer cancel()
+	return w.WriteLogContext(ctx, req)
+}
+
+// WriteLogContext publishes req and waits for the server to store it until
+// ctx is done. It returns ErrUnroutable or ErrNacked when the broker does not
+// take the log, and a *ResponseError when the server does not store it.
+func (w *Writter) WriteLogContext(ctx context.Context, req serverPresentation.AMQPLogRequest) error {
+	if req.Date.IsZero() {
+		req.Date = time.Now()
 	}
-	if err := w.logPresentation.Serve(w.msgs, id); err != nil {
-		return 0, err
+	if req.SourceService == "" {
+		req.SourceService = w.sourceService
 	}
-	return len(p), nil
+
+	id := uuid.New().String()
+	replies, cancel := w.replies.Register(id)
+	defer cancel()
+	if err := w.logPresentation.Publish(ctx, w.queue, id, req); err != nil {
+		return err
+	}
+	return w.logPresentation.Serve(ctx, replies, id)
 }
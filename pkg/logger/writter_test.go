@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"log_service/internal/client/presentation"
+	serverPresentation "log_service/internal/server/presentation"
+	"log_service/internal/utils"
+)
+
+// replyingPresentation responds to every published log from another
+// goroutine, rejecting the logs whose content is "invalid".
+type replyingPresentation struct {
+	presentation.LogPresentation
+	msgs chan amqp.Delivery
+}
+
+func (p *replyingPresentation) Publish(_ context.Context, _ string, id string, req serverPresentation.AMQPLogRequest) error {
+	res := serverPresentation.AmqpLogResponse{StatusCode: utils.OK}
+	if req.Content == "invalid" {
+		res = serverPresentation.AmqpLogResponse{StatusCode: utils.INVALID_ARGUMENT, Message: "Invalid log request"}
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	go func() { p.msgs <- amqp.Delivery{CorrelationId: id, Body: body} }()
+	return nil
+}
+
+func TestWritterConcurrentWrites(t *testing.T) {
+	t.Parallel()
+	msgs := make(chan amqp.Delivery)
+	defer close(msgs)
+	w := &Writter{
+		logPresentation: &replyingPresentation{msgs: msgs},
+		replies:         presentation.NewReplyDispatcher(msgs),
+	}
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content := fmt.Sprintf("log %d", i)
+			var wantErr error
+			if i%10 == 0 {
+				content, wantErr = "invalid", ErrInvalidArgument
+			}
+			err := w.WriteLog(serverPresentation.AMQPLogRequest{Content: content})
+			if !errors.Is(err, wantErr) {
+				t.Errorf("WriteLog(%q) error = %v, want %v", content, err, wantErr)
+			}
+		}()
+	}
+	wg.Wait()
+}